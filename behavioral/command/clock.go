@@ -0,0 +1,127 @@
+package command
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock abstracts time so schedulers can be driven by a fake clock in tests.
+type Clock interface {
+	Now() time.Time
+	// NewTimer delivers the current time on the timer's channel once d has elapsed.
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single pending wake-up from a Clock. Stop releases it if it has not fired.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+// RealClock is a Clock backed by the time package.
+type RealClock struct{}
+
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+func (RealClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+type realTimer struct {
+	t *time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.t.C
+}
+
+func (t realTimer) Stop() bool {
+	return t.t.Stop()
+}
+
+// FakeClock is a Clock that only moves when Advance or Set is called.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*fakeTimer // Pending timers; fired and stopped ones are removed
+}
+
+type fakeTimer struct {
+	clock    *FakeClock
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, deadline: c.now.Add(d), ch: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.ch <- c.now
+		return t
+	}
+	c.waiters = append(c.waiters, t)
+	return t
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+// Stop removes the timer from its clock, so abandoned timers do not pile up.
+func (t *fakeTimer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, w := range c.waiters {
+		if w == t {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Advance moves the clock forward by d and fires every timer that is now due.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	now := c.now.Add(d)
+	c.mu.Unlock()
+	c.Set(now)
+}
+
+// Set moves the clock to now and fires every timer that is due by then.
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+	remaining := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.deadline.After(now) {
+			remaining = append(remaining, w)
+			continue
+		}
+		w.ch <- now
+	}
+	clear(c.waiters[len(remaining):])
+	c.waiters = remaining
+}
+
+// pending reports how many timers are waiting to fire.
+func (c *FakeClock) pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
@@ -0,0 +1,193 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNothingToUndo is returned by Undo when the command has not been executed.
+var ErrNothingToUndo = errors.New("command has nothing to undo")
+
+// --- 5. Receiver ---
+// The Light knows how to perform the actual actions (turn on/off).
+type Light struct {
+	isOn bool
+}
+
+func (l *Light) TurnOn() {
+	l.isOn = true
+	fmt.Println("Light is ON")
+}
+
+func (l *Light) TurnOff() {
+	l.isOn = false
+	fmt.Println("Light is OFF")
+}
+
+func (l *Light) IsOn() bool {
+	return l.isOn
+}
+
+// DeviceType names the kind of a receiver; command kinds are "<type>.<action>".
+func (l *Light) DeviceType() string {
+	return "light"
+}
+
+// LightState is the JSON view of a Light.
+type LightState struct {
+	On bool `json:"on"`
+}
+
+func (l *Light) State() any {
+	return LightState{On: l.isOn}
+}
+
+// setOn restores a previously observed state of the light.
+func (l *Light) setOn(on bool) {
+	if on {
+		l.TurnOn()
+	} else {
+		l.TurnOff()
+	}
+}
+
+// --- 1. Command (Interface) ---
+// Declares an interface for executing an operation and reverting it.
+// Both methods report failures so invokers can retry, compensate or give up.
+type Command interface {
+	Execute() error
+	Undo() error // Reverts the effect of the most recent Execute
+}
+
+// --- 2. Concrete Commands ---
+
+// TurnOnCommand encapsulates the request to turn the light on.
+type TurnOnCommand struct {
+	light      *Light // Reference to the Receiver
+	prevStates []bool // State of the light before each Execute, most recent last
+}
+
+func NewTurnOnCommand(light *Light) *TurnOnCommand {
+	return &TurnOnCommand{light: light}
+}
+
+func (c *TurnOnCommand) Execute() error {
+	c.prevStates = append(c.prevStates, c.light.isOn)
+	c.light.TurnOn() // Delegates the actual action to the Receiver
+	return nil
+}
+
+func (c *TurnOnCommand) Undo() error {
+	if len(c.prevStates) == 0 {
+		return ErrNothingToUndo
+	}
+	prev := c.prevStates[len(c.prevStates)-1]
+	c.prevStates = c.prevStates[:len(c.prevStates)-1]
+	c.light.setOn(prev) // Restores the previous state instead of just flipping it
+	return nil
+}
+
+// Forget drops the state saved by the oldest Execute; see Forgetter.
+func (c *TurnOnCommand) Forget() {
+	forgetOldest(&c.prevStates)
+}
+
+// TurnOffCommand encapsulates the request to turn the light off.
+type TurnOffCommand struct {
+	light      *Light // Reference to the Receiver
+	prevStates []bool // State of the light before each Execute, most recent last
+}
+
+func NewTurnOffCommand(light *Light) *TurnOffCommand {
+	return &TurnOffCommand{light: light}
+}
+
+func (c *TurnOffCommand) Execute() error {
+	c.prevStates = append(c.prevStates, c.light.isOn)
+	c.light.TurnOff() // Delegates the actual action to the Receiver
+	return nil
+}
+
+func (c *TurnOffCommand) Undo() error {
+	if len(c.prevStates) == 0 {
+		return ErrNothingToUndo
+	}
+	prev := c.prevStates[len(c.prevStates)-1]
+	c.prevStates = c.prevStates[:len(c.prevStates)-1]
+	c.light.setOn(prev)
+	return nil
+}
+
+func (c *TurnOffCommand) Forget() {
+	forgetOldest(&c.prevStates)
+}
+
+// --- 4. Invoker ---
+// A simple remote control button that can execute any Command.
+// It remembers executed commands so they can be undone and redone.
+type RemoteControl struct {
+	command Command // Holds a Command object
+	history *History
+}
+
+// NewRemoteControl creates a remote whose undo/redo history keeps at most historyLimit commands.
+func NewRemoteControl(historyLimit int) *RemoteControl {
+	return &RemoteControl{history: NewHistory(historyLimit)}
+}
+
+func (rc *RemoteControl) SetCommand(cmd Command) {
+	rc.command = cmd
+}
+
+func (rc *RemoteControl) PressButton() error {
+	if rc.command == nil {
+		fmt.Println("RemoteControl: No command set for button.")
+		return nil
+	}
+	fmt.Print("RemoteControl: Button pressed. ")
+	// Invokes the command without knowing its details
+	if err := rc.command.Execute(); err != nil {
+		fmt.Printf("RemoteControl: Command failed: %v\n", err)
+		return err
+	}
+	rc.getHistory().Push(rc.command)
+	return nil
+}
+
+func (rc *RemoteControl) PressUndo() error {
+	cmd, ok := rc.getHistory().Undo()
+	if !ok {
+		fmt.Println("RemoteControl: Nothing to undo.")
+		return nil
+	}
+	fmt.Print("RemoteControl: Undo pressed. ")
+	if err := cmd.Undo(); err != nil {
+		fmt.Printf("RemoteControl: Undo failed: %v\n", err)
+		rc.history.Redo() // The command is still in effect, keep it undoable
+		return err
+	}
+	return nil
+}
+
+func (rc *RemoteControl) PressRedo() error {
+	cmd, ok := rc.getHistory().Redo()
+	if !ok {
+		fmt.Println("RemoteControl: Nothing to redo.")
+		return nil
+	}
+	fmt.Print("RemoteControl: Redo pressed. ")
+	if err := cmd.Execute(); err != nil {
+		fmt.Printf("RemoteControl: Redo failed: %v\n", err)
+		rc.history.Undo() // The command did not take effect, keep it redoable
+		return err
+	}
+	return nil
+}
+
+// getHistory lazily creates the history so the zero value RemoteControl stays usable.
+func (rc *RemoteControl) getHistory() *History {
+	if rc.history == nil {
+		rc.history = NewHistory(DefaultHistoryLimit)
+	}
+	return rc.history
+}
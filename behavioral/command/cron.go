@@ -0,0 +1,167 @@
+package command
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a recurring schedule in the classic five-field cron format:
+//
+//	minute hour day-of-month month day-of-week
+//
+// Each field accepts "*", numbers, ranges ("1-5"), lists ("1,15") and steps ("*/15").
+// Months and weekdays may also be written as three-letter names ("jan", "mon-fri").
+// As in cron, when both day fields are restricted a day matching either one qualifies.
+type CronSchedule struct {
+	spec     string
+	minutes  fieldSet
+	hours    fieldSet
+	days     fieldSet
+	months   fieldSet
+	weekdays fieldSet
+	anyDay   bool // day-of-month is "*"
+	anyWeek  bool // day-of-week is "*"
+}
+
+type fieldSet map[int]bool
+
+var (
+	monthNames   = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+	weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+)
+
+// ParseCron parses a five-field cron spec such as "0 7 * * mon-fri".
+func ParseCron(spec string) (*CronSchedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron spec %q: expected 5 fields, got %d", spec, len(fields))
+	}
+
+	s := &CronSchedule{
+		spec:    spec,
+		anyDay:  fields[2] == "*",
+		anyWeek: fields[4] == "*",
+	}
+	var err error
+	if s.minutes, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("cron spec %q minute: %w", spec, err)
+	}
+	if s.hours, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("cron spec %q hour: %w", spec, err)
+	}
+	if s.days, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("cron spec %q day of month: %w", spec, err)
+	}
+	if s.months, err = parseCronField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("cron spec %q month: %w", spec, err)
+	}
+	if s.weekdays, err = parseCronField(fields[4], 0, 7, weekdayNames); err != nil {
+		return nil, fmt.Errorf("cron spec %q day of week: %w", spec, err)
+	}
+	if s.weekdays[7] { // Both 0 and 7 mean Sunday
+		s.weekdays[0] = true
+	}
+	return s, nil
+}
+
+func (s *CronSchedule) String() string {
+	return s.spec
+}
+
+// Next returns the first matching minute strictly after t, in t's location.
+// It gives up and returns the zero time if nothing matches within five years
+// (e.g. "0 0 31 feb *").
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if !s.months[int(t.Month())] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.hours[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.minutes[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	day := s.days[t.Day()]
+	week := s.weekdays[int(t.Weekday())]
+	switch {
+	case s.anyDay && s.anyWeek:
+		return true
+	case s.anyDay:
+		return week
+	case s.anyWeek:
+		return day
+	default:
+		return day || week
+	}
+}
+
+func parseCronField(field string, lo, hi int, names []string) (fieldSet, error) {
+	set := make(fieldSet)
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid step %q", stepPart)
+			}
+			step = n
+		}
+
+		from, to := lo, hi
+		if rangePart != "*" {
+			startPart, endPart, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if from, err = parseCronValue(startPart, lo, hi, names); err != nil {
+				return nil, err
+			}
+			to = from
+			if isRange {
+				if to, err = parseCronValue(endPart, lo, hi, names); err != nil {
+					return nil, err
+				}
+			} else if hasStep {
+				to = hi // "5/15" means every 15 starting at 5
+			}
+			if from > to {
+				return nil, fmt.Errorf("invalid range %q", rangePart)
+			}
+		}
+
+		for v := from; v <= to; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
+
+func parseCronValue(value string, lo, hi int, names []string) (int, error) {
+	for i, name := range names {
+		if strings.EqualFold(value, name) {
+			return i + lo, nil
+		}
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < lo || n > hi {
+		return 0, fmt.Errorf("value %q out of range %d-%d", value, lo, hi)
+	}
+	return n, nil
+}
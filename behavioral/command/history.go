@@ -0,0 +1,96 @@
+package command
+
+// DefaultHistoryLimit is used when a History is created without a positive limit.
+const DefaultHistoryLimit = 10
+
+// Forgetter is implemented by commands that remember a prior state on every Execute.
+// When History evicts a command's oldest execution it calls Forget, so the command can
+// drop the state that execution saved and does not grow without bound.
+type Forgetter interface {
+	Forget()
+}
+
+// History keeps bounded undo and redo stacks of executed commands.
+// When the undo stack is full, the oldest command is forgotten.
+type History struct {
+	limit int
+	undo  []Command
+	redo  []Command
+}
+
+func NewHistory(limit int) *History {
+	if limit <= 0 {
+		limit = DefaultHistoryLimit
+	}
+	return &History{limit: limit}
+}
+
+// Push records a freshly executed command. Any redoable commands are discarded,
+// since they belong to a branch of history that no longer exists.
+func (h *History) Push(cmd Command) {
+	h.pushUndo(cmd)
+	h.redo = nil
+}
+
+// Undo moves the most recent command to the redo stack and returns it.
+// The caller is responsible for calling its Undo method.
+func (h *History) Undo() (Command, bool) {
+	if len(h.undo) == 0 {
+		return nil, false
+	}
+	cmd := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	h.redo = pushBounded(h.redo, cmd, h.limit)
+	return cmd, true
+}
+
+// Redo moves the most recently undone command back to the undo stack and returns it.
+// The caller is responsible for executing it again.
+func (h *History) Redo() (Command, bool) {
+	if len(h.redo) == 0 {
+		return nil, false
+	}
+	cmd := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	h.pushUndo(cmd)
+	return cmd, true
+}
+
+func (h *History) CanUndo() bool {
+	return len(h.undo) > 0
+}
+
+func (h *History) CanRedo() bool {
+	return len(h.redo) > 0
+}
+
+// pushUndo pushes cmd, forgetting the oldest executions that no longer fit.
+func (h *History) pushUndo(cmd Command) {
+	if excess := len(h.undo) - h.limit + 1; excess > 0 {
+		for _, old := range h.undo[:excess] {
+			forget(old)
+		}
+	}
+	h.undo = pushBounded(h.undo, cmd, h.limit)
+}
+
+func forget(cmd Command) {
+	if f, ok := cmd.(Forgetter); ok {
+		f.Forget()
+	}
+}
+
+// forgetOldest drops the bottom of a stack of prior states.
+func forgetOldest[T any](stack *[]T) {
+	if len(*stack) > 0 {
+		*stack = append((*stack)[:0:0], (*stack)[1:]...)
+	}
+}
+
+func pushBounded(stack []Command, cmd Command, limit int) []Command {
+	if len(stack) >= limit {
+		// Drop the oldest entries; copy so the backing array does not grow forever.
+		stack = append(stack[:0:0], stack[len(stack)-limit+1:]...)
+	}
+	return append(stack, cmd)
+}
@@ -0,0 +1,77 @@
+package command
+
+import "testing"
+
+func TestRemoteControlUndoRedo(t *testing.T) {
+	tests := []struct {
+		name   string
+		steps  string // o = press on, f = press off, u = undo, r = redo
+		wantOn bool
+	}{
+		{"on", "o", true},
+		{"undo on", "ou", false},
+		{"repeated on then undo keeps on", "oou", true},
+		{"off after on then undo", "ofu", true},
+		{"undo then redo", "ofur", false},
+		{"new command clears redo", "ofuor", true},
+		{"undo past the start", "ouuu", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			light := &Light{}
+			on, off := NewTurnOnCommand(light), NewTurnOffCommand(light)
+			rc := NewRemoteControl(5)
+			for _, step := range tt.steps {
+				var err error
+				switch step {
+				case 'o':
+					rc.SetCommand(on)
+					err = rc.PressButton()
+				case 'f':
+					rc.SetCommand(off)
+					err = rc.PressButton()
+				case 'u':
+					err = rc.PressUndo()
+				case 'r':
+					err = rc.PressRedo()
+				}
+				if err != nil {
+					t.Fatalf("step %c: %v", step, err)
+				}
+			}
+			if light.IsOn() != tt.wantOn {
+				t.Errorf("light on = %t, want %t", light.IsOn(), tt.wantOn)
+			}
+		})
+	}
+}
+
+func TestHistoryEvictionForgetsPriorStates(t *testing.T) {
+	light := &Light{}
+	on := NewTurnOnCommand(light)
+	dimmer := &DimmableLight{}
+	dim := NewMacroCommand(NewSetLevelCommand(dimmer, 40))
+	rc := NewRemoteControl(3)
+	for range 100 {
+		rc.SetCommand(on)
+		if err := rc.PressButton(); err != nil {
+			t.Fatal(err)
+		}
+		rc.SetCommand(dim)
+		if err := rc.PressButton(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	level := dim.commands[0].(*SetLevelCommand)
+	if got := len(on.prevStates) + len(level.prevLevels); got != 3 {
+		t.Fatalf("saved prior states = %d, want 3 (the history limit)", got)
+	}
+	for rc.getHistory().CanUndo() {
+		if err := rc.PressUndo(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(on.prevStates) != 0 || len(level.prevLevels) != 0 {
+		t.Errorf("prior states left after undoing everything: %v, %v", on.prevStates, level.prevLevels)
+	}
+}
@@ -0,0 +1,247 @@
+package command
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+)
+
+// Each journal record is framed as:
+//
+//	[4 bytes payload length][4 bytes CRC-32 of payload][payload]
+//
+// where the payload is a JSON encoded JournalRecord. The frame lets a reader tell a
+// complete record from one that was torn by a crash in the middle of a write.
+const (
+	journalHeaderSize = 8
+	maxJournalRecord  = 1 << 20 // Anything larger is treated as a corrupt length field
+)
+
+// ErrCorruptJournal is returned when a bad record is followed by more data. Only the
+// last record can be torn by a crash, so anything else is real damage that truncating
+// would silently discard.
+var ErrCorruptJournal = errors.New("journal is corrupt")
+
+// journalFile is the part of *os.File the journal uses; tests substitute failing writers.
+type journalFile interface {
+	io.Writer
+	io.Seeker
+	io.Closer
+	Sync() error
+	Truncate(size int64) error
+}
+
+// JournalRecord is a single executed command. The spec kind acts as the type tag.
+type JournalRecord struct {
+	Seq  uint64      `json:"seq"`
+	Spec CommandSpec `json:"spec"`
+}
+
+// Journal is an append-only log of executed commands. Replaying it against fresh
+// receivers rebuilds their state, which gives the Command pattern an event-sourcing use.
+type Journal struct {
+	file     journalFile
+	registry *Registry
+	lastSeq  uint64
+	size     int64 // Offset just past the last complete record
+	err      error // Set when a failed append could not be rolled back; the journal is unusable
+}
+
+// OpenJournal opens (or creates) the journal at path and replays every intact record
+// against the receivers known to reg. A torn or corrupt last record is truncated so
+// startup can continue after a crash. A bad record in the middle of the file returns
+// ErrCorruptJournal, and an intact record that cannot be built is an error too.
+func OpenJournal(path string, reg *Registry) (*Journal, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open journal: %w", err)
+	}
+
+	records, validSize, err := readJournal(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if err := file.Truncate(validSize); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("truncate journal tail: %w", err)
+	}
+	if _, err := file.Seek(validSize, io.SeekStart); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("seek journal: %w", err)
+	}
+
+	j := &Journal{file: file, registry: reg, size: validSize}
+	for _, rec := range records {
+		cmd, err := reg.Build(rec.Spec)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("replay record %d: %w", rec.Seq, err)
+		}
+		if err := cmd.Execute(); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("replay record %d: %w", rec.Seq, err)
+		}
+		j.lastSeq = rec.Seq
+	}
+	return j, nil
+}
+
+// Execute builds and runs the command described by spec, then durably appends it
+// to the journal. Failed commands are not journaled, and a command whose record
+// cannot be written is undone so the receivers never run ahead of the journal.
+func (j *Journal) Execute(spec CommandSpec) error {
+	if j.err != nil {
+		return j.err
+	}
+	cmd, err := j.registry.Build(spec)
+	if err != nil {
+		return err
+	}
+	if err := cmd.Execute(); err != nil {
+		return err
+	}
+	rec := JournalRecord{Seq: j.lastSeq + 1, Spec: spec}
+	if err := j.append(rec); err != nil {
+		if undoErr := cmd.Undo(); undoErr != nil {
+			return errors.Join(err, fmt.Errorf("undo unjournaled command: %w", undoErr))
+		}
+		return err
+	}
+	j.lastSeq = rec.Seq
+	return nil
+}
+
+// LastSeq returns the sequence number of the most recent record, or 0 for an empty journal.
+func (j *Journal) LastSeq() uint64 {
+	return j.lastSeq
+}
+
+func (j *Journal) Close() error {
+	return j.file.Close()
+}
+
+func (j *Journal) append(rec JournalRecord) error {
+	payload, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("encode journal record: %w", err)
+	}
+	frame := make([]byte, journalHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(frame[0:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(frame[4:8], crc32.ChecksumIEEE(payload))
+	copy(frame[journalHeaderSize:], payload)
+
+	if _, err := j.file.Write(frame); err != nil {
+		return j.rollback(fmt.Errorf("append journal record: %w", err))
+	}
+	if err := j.file.Sync(); err != nil {
+		return j.rollback(fmt.Errorf("sync journal: %w", err))
+	}
+	j.size += int64(len(frame))
+	return nil
+}
+
+// rollback cuts a partly written record off the end of the file, so the next append
+// does not land behind garbage that would hide it on replay. If that fails too, the
+// journal refuses further appends.
+func (j *Journal) rollback(err error) error {
+	if terr := j.file.Truncate(j.size); terr != nil {
+		j.err = fmt.Errorf("journal unusable after failed append: %w", errors.Join(err, terr))
+		return j.err
+	}
+	if _, serr := j.file.Seek(j.size, io.SeekStart); serr != nil {
+		j.err = fmt.Errorf("journal unusable after failed append: %w", errors.Join(err, serr))
+		return j.err
+	}
+	return err
+}
+
+// readJournal returns every intact record and the byte offset just past the last one.
+// A bad record at the end of the file is a torn tail and ends the scan; a bad record
+// with more data behind it is mid-file corruption and returns ErrCorruptJournal.
+func readJournal(r io.Reader) ([]JournalRecord, int64, error) {
+	br := bufio.NewReader(r)
+	var (
+		records   []JournalRecord
+		validSize int64
+		header    [journalHeaderSize]byte
+	)
+	// badRecord ends the scan at the current record. A crash can only tear the last
+	// write, so the record is a torn tail when trailing reports nothing behind it.
+	badRecord := func(reason string, trailing func(*bufio.Reader) (bool, error)) ([]JournalRecord, int64, error) {
+		more, err := trailing(br)
+		if err != nil {
+			return nil, 0, fmt.Errorf("read journal: %w", err)
+		}
+		if more {
+			return nil, 0, fmt.Errorf("%w: record %d at offset %d: %s",
+				ErrCorruptJournal, len(records)+1, validSize, reason)
+		}
+		return records, validSize, nil
+	}
+	for {
+		if _, err := io.ReadFull(br, header[:]); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return records, validSize, nil
+			}
+			return nil, 0, fmt.Errorf("read journal: %w", err)
+		}
+		size := binary.BigEndian.Uint32(header[0:4])
+		if size == 0 || size > maxJournalRecord {
+			// The length cannot be trusted, so judge by what follows the header: zeros
+			// are space the file system allocated but the crash never filled.
+			return badRecord(fmt.Sprintf("invalid length %d", size), hasNonZero)
+		}
+		payload := make([]byte, size)
+		if _, err := io.ReadFull(br, payload); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return records, validSize, nil
+			}
+			return nil, 0, fmt.Errorf("read journal: %w", err)
+		}
+		if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(header[4:8]) {
+			return badRecord("checksum mismatch", hasMore)
+		}
+		var rec JournalRecord
+		if err := json.Unmarshal(payload, &rec); err != nil {
+			return badRecord(err.Error(), hasMore)
+		}
+		if len(records) > 0 && rec.Seq != records[len(records)-1].Seq+1 {
+			return badRecord(fmt.Sprintf("sequence %d after %d", rec.Seq, records[len(records)-1].Seq), hasMore)
+		}
+		records = append(records, rec)
+		validSize += int64(journalHeaderSize) + int64(size)
+	}
+}
+
+// hasMore reports whether any data is left to read.
+func hasMore(br *bufio.Reader) (bool, error) {
+	if _, err := br.Peek(1); err != nil {
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// hasNonZero reports whether any data left to read is not a zero byte.
+func hasNonZero(br *bufio.Reader) (bool, error) {
+	for {
+		b, err := br.ReadByte()
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if b != 0 {
+			return true, nil
+		}
+	}
+}
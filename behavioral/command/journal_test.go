@@ -0,0 +1,289 @@
+package command
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func porchRegistry() (*Registry, *Light) {
+	porch := &Light{}
+	reg := NewRegistry()
+	reg.RegisterReceiver("porch", porch)
+	return reg, porch
+}
+
+func toggle(seq int) CommandSpec {
+	if seq%2 == 1 {
+		return CommandSpec{Kind: "light.on", Receiver: "porch"}
+	}
+	return CommandSpec{Kind: "light.off", Receiver: "porch"}
+}
+
+// writeJournal journals n alternating on/off commands, starting with on.
+func writeJournal(t *testing.T, path string, n int) {
+	t.Helper()
+	reg, _ := porchRegistry()
+	j, err := OpenJournal(path, reg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+	for seq := 1; seq <= n; seq++ {
+		if err := j.Execute(toggle(seq)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestJournalRecoversFromTornTail(t *testing.T) {
+	dir := t.TempDir()
+	complete := filepath.Join(dir, "complete")
+	writeJournal(t, complete, 3)
+	full, err := os.ReadFile(complete)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeJournal(t, filepath.Join(dir, "two"), 2)
+	two, _ := os.ReadFile(filepath.Join(dir, "two"))
+
+	corrupt := append([]byte(nil), full...)
+	corrupt[len(two)+journalHeaderSize] ^= 0xff // Flip a payload byte of record 3
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantSeq uint64
+	}{
+		{"empty", nil, 0},
+		{"complete", full, 3},
+		{"garbage tail", append(append([]byte(nil), full...), 0, 0, 0, 42, 1, 2), 3},
+		{"zero length tail", append(append([]byte(nil), full...), make([]byte, 16)...), 3},
+		{"corrupt last record", corrupt, 2},
+	}
+	// A crash can cut the last write off after any byte.
+	for cut := len(two) + 1; cut < len(full); cut++ {
+		tests = append(tests, struct {
+			name    string
+			data    []byte
+			wantSeq uint64
+		}{"killed after byte " + strconv.Itoa(cut), full[:cut], 2})
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "journal")
+			if err := os.WriteFile(path, tt.data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			reg, porch := porchRegistry()
+			j, err := OpenJournal(path, reg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if j.LastSeq() != tt.wantSeq {
+				t.Errorf("LastSeq = %d, want %d", j.LastSeq(), tt.wantSeq)
+			}
+			if want := tt.wantSeq%2 == 1; porch.IsOn() != want {
+				t.Errorf("porch on = %t, want %t", porch.IsOn(), want)
+			}
+
+			// Appending after recovery must produce a journal that replays completely.
+			if err := j.Execute(toggle(int(tt.wantSeq) + 1)); err != nil {
+				t.Fatal(err)
+			}
+			j.Close()
+			reg, _ = porchRegistry()
+			j, err = OpenJournal(path, reg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer j.Close()
+			if j.LastSeq() != tt.wantSeq+1 {
+				t.Errorf("LastSeq after append and reopen = %d, want %d", j.LastSeq(), tt.wantSeq+1)
+			}
+		})
+	}
+}
+
+func TestJournalRejectsMidFileCorruption(t *testing.T) {
+	dir := t.TempDir()
+	complete := filepath.Join(dir, "complete")
+	writeJournal(t, complete, 3)
+	full, err := os.ReadFile(complete)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeJournal(t, filepath.Join(dir, "one"), 1)
+	one, _ := os.ReadFile(filepath.Join(dir, "one"))
+	writeJournal(t, filepath.Join(dir, "two"), 2)
+	two, _ := os.ReadFile(filepath.Join(dir, "two"))
+
+	damage := func(offset int, b byte) []byte {
+		data := append([]byte(nil), full...)
+		data[offset] ^= b
+		return data
+	}
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"payload of record 2", damage(len(one)+journalHeaderSize, 0xff)},
+		{"checksum of record 1", damage(4, 0x01)},
+		{"length of record 2", damage(len(one), 0xff)},
+		{"zeroed length of record 2", append(append(append([]byte(nil), one...), make([]byte, 4)...), full[len(one)+4:]...)},
+		{"sequence gap", append(append(append([]byte(nil), one...), full[len(two):]...), full[len(two):]...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "journal")
+			if err := os.WriteFile(path, tt.data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			reg, _ := porchRegistry()
+			if j, err := OpenJournal(path, reg); !errors.Is(err, ErrCorruptJournal) {
+				if j != nil {
+					j.Close()
+				}
+				t.Fatalf("OpenJournal = %v, want ErrCorruptJournal", err)
+			}
+			if data, _ := os.ReadFile(path); len(data) != len(tt.data) {
+				t.Errorf("journal truncated to %d bytes, want %d untouched", len(data), len(tt.data))
+			}
+		})
+	}
+}
+
+// tornFile writes only the first limit bytes of a write, then fails, like a disk
+// that fills up in the middle of a record.
+type tornFile struct {
+	journalFile
+	limit int
+}
+
+func (f *tornFile) Write(p []byte) (int, error) {
+	n, err := f.journalFile.Write(p[:min(f.limit, len(p))])
+	if err == nil {
+		err = errors.New("disk full")
+	}
+	return n, err
+}
+
+func TestJournalRollsBackFailedAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal")
+	reg, porch := porchRegistry()
+	j, err := OpenJournal(path, reg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Execute(toggle(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	good := j.file
+	j.file = &tornFile{journalFile: good, limit: 5}
+	if err := j.Execute(toggle(2)); err == nil {
+		t.Fatal("Execute succeeded with a failing journal")
+	}
+	if !porch.IsOn() {
+		t.Error("unjournaled command was not undone")
+	}
+
+	j.file = good
+	if err := j.Execute(toggle(2)); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Execute(toggle(3)); err != nil {
+		t.Fatal(err)
+	}
+	j.Close()
+
+	reg, porch = porchRegistry()
+	j, err = OpenJournal(path, reg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+	if j.LastSeq() != 3 || !porch.IsOn() {
+		t.Errorf("after reopen LastSeq = %d, on = %t; want 3, true", j.LastSeq(), porch.IsOn())
+	}
+}
+
+// TestJournalHelperProcess is not a real test: TestJournalSurvivesKill runs it in a
+// child process that journals commands until it is killed.
+func TestJournalHelperProcess(t *testing.T) {
+	path := os.Getenv("GOF_JOURNAL_HELPER")
+	if path == "" {
+		t.Skip("helper process only")
+	}
+	reg, _ := porchRegistry()
+	j, err := OpenJournal(path, reg)
+	if err != nil {
+		os.Exit(2)
+	}
+	first := int(j.LastSeq()) + 1
+	for seq := first; ; seq++ {
+		if err := j.Execute(toggle(seq)); err != nil {
+			os.Exit(3)
+		}
+		if seq == first {
+			os.Stdout.WriteString("ready\n")
+		}
+		time.Sleep(100 * time.Microsecond) // Keep the journal small enough to replay quickly
+	}
+}
+
+func TestJournalSurvivesKill(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts child processes")
+	}
+	path := filepath.Join(t.TempDir(), "journal")
+	var lastSeq uint64
+	for run := range 3 {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestJournalHelperProcess$")
+		cmd.Env = append(os.Environ(), "GOF_JOURNAL_HELPER="+path)
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := cmd.Start(); err != nil {
+			t.Fatal(err)
+		}
+		// The receivers print too, so skip lines until the helper reports it is running.
+		lines := bufio.NewScanner(stdout)
+		for lines.Scan() && lines.Text() != "ready" {
+		}
+		if lines.Text() != "ready" {
+			cmd.Process.Kill()
+			cmd.Wait()
+			t.Fatalf("run %d: helper did not start: %v", run, lines.Err())
+		}
+		go func() {
+			for lines.Scan() { // Keep the pipe drained so the helper never blocks on stdout
+			}
+		}()
+		time.Sleep(time.Duration(run+1) * 10 * time.Millisecond)
+		cmd.Process.Kill()
+		cmd.Wait()
+
+		reg, porch := porchRegistry()
+		j, err := OpenJournal(path, reg)
+		if err != nil {
+			t.Fatalf("run %d: reopen after kill: %v", run, err)
+		}
+		seq := j.LastSeq()
+		j.Close()
+		if seq < lastSeq {
+			t.Fatalf("run %d: LastSeq went back from %d to %d", run, lastSeq, seq)
+		}
+		if want := seq%2 == 1; porch.IsOn() != want {
+			t.Fatalf("run %d: porch on = %t after %d commands", run, porch.IsOn(), seq)
+		}
+		lastSeq = seq
+	}
+}
@@ -0,0 +1,165 @@
+package command
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// registerBuiltinKinds teaches a registry the commands of every receiver in this package:
+//
+//	light.on, light.off
+//	dimmer.dim <level>, dimmer.on, dimmer.off
+//	thermostat.set <celsius>, thermostat.mode <off|heat|cool|auto>
+//	blind.position <percent>, blind.open, blind.close
+func registerBuiltinKinds(reg *Registry) {
+	reg.RegisterKind("light.on", lightKind("light.on", func(l *Light) Command { return NewTurnOnCommand(l) }))
+	reg.RegisterKind("light.off", lightKind("light.off", func(l *Light) Command { return NewTurnOffCommand(l) }))
+
+	reg.RegisterKind("dimmer.dim", func(receiver any, args []string) (Command, error) {
+		dimmer, err := receiverAs[*DimmableLight]("dimmer.dim", receiver)
+		if err != nil {
+			return nil, err
+		}
+		level, err := intArg("dimmer.dim", args)
+		if err != nil {
+			return nil, err
+		}
+		return NewSetLevelCommand(dimmer, level), nil
+	})
+	reg.RegisterKind("dimmer.on", dimmerPreset("dimmer.on", 100))
+	reg.RegisterKind("dimmer.off", dimmerPreset("dimmer.off", 0))
+
+	reg.RegisterKind("thermostat.set", func(receiver any, args []string) (Command, error) {
+		thermostat, err := receiverAs[*Thermostat]("thermostat.set", receiver)
+		if err != nil {
+			return nil, err
+		}
+		if len(args) != 1 {
+			return nil, fmt.Errorf("thermostat.set expects 1 argument, got %d", len(args))
+		}
+		celsius, err := strconv.ParseFloat(args[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("thermostat.set: invalid temperature %q", args[0])
+		}
+		return NewSetSetpointCommand(thermostat, celsius), nil
+	})
+	reg.RegisterKind("thermostat.mode", func(receiver any, args []string) (Command, error) {
+		thermostat, err := receiverAs[*Thermostat]("thermostat.mode", receiver)
+		if err != nil {
+			return nil, err
+		}
+		if len(args) != 1 {
+			return nil, fmt.Errorf("thermostat.mode expects 1 argument, got %d", len(args))
+		}
+		return NewSetModeCommand(thermostat, ThermostatMode(strings.ToLower(args[0]))), nil
+	})
+
+	reg.RegisterKind("blind.position", func(receiver any, args []string) (Command, error) {
+		blind, err := receiverAs[*Blind]("blind.position", receiver)
+		if err != nil {
+			return nil, err
+		}
+		position, err := intArg("blind.position", args)
+		if err != nil {
+			return nil, err
+		}
+		return NewSetPositionCommand(blind, position), nil
+	})
+	reg.RegisterKind("blind.open", blindPreset("blind.open", 100))
+	reg.RegisterKind("blind.close", blindPreset("blind.close", 0))
+}
+
+// ParseSpec turns a line such as "living.dim 40" into a CommandSpec. The part before
+// the dot names a registered receiver; the action is resolved against its DeviceType.
+func (reg *Registry) ParseSpec(line string) (CommandSpec, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return CommandSpec{}, fmt.Errorf("empty command")
+	}
+	name, action, ok := strings.Cut(fields[0], ".")
+	if !ok || name == "" || action == "" {
+		return CommandSpec{}, fmt.Errorf("command %q must look like <receiver>.<action>", fields[0])
+	}
+	receiver, ok := reg.receivers[name]
+	if !ok {
+		return CommandSpec{}, fmt.Errorf("unknown receiver %q", name)
+	}
+	typed, ok := receiver.(interface{ DeviceType() string })
+	if !ok {
+		return CommandSpec{}, fmt.Errorf("receiver %q (%T) has no device type", name, receiver)
+	}
+	spec := CommandSpec{Kind: typed.DeviceType() + "." + action, Receiver: name}
+	if len(fields) > 1 {
+		spec.Args = fields[1:]
+	}
+	return spec, nil
+}
+
+// ParseLine parses and builds a command in one step.
+func (reg *Registry) ParseLine(line string) (Command, error) {
+	spec, err := reg.ParseSpec(line)
+	if err != nil {
+		return nil, err
+	}
+	return reg.Build(spec)
+}
+
+func lightKind(kind string, newCommand func(*Light) Command) CommandFactory {
+	return func(receiver any, args []string) (Command, error) {
+		light, err := receiverAs[*Light](kind, receiver)
+		if err != nil {
+			return nil, err
+		}
+		if len(args) != 0 {
+			return nil, fmt.Errorf("%s takes no arguments, got %d", kind, len(args))
+		}
+		return newCommand(light), nil
+	}
+}
+
+func dimmerPreset(kind string, level int) CommandFactory {
+	return func(receiver any, args []string) (Command, error) {
+		dimmer, err := receiverAs[*DimmableLight](kind, receiver)
+		if err != nil {
+			return nil, err
+		}
+		if len(args) != 0 {
+			return nil, fmt.Errorf("%s takes no arguments, got %d", kind, len(args))
+		}
+		return NewSetLevelCommand(dimmer, level), nil
+	}
+}
+
+func blindPreset(kind string, position int) CommandFactory {
+	return func(receiver any, args []string) (Command, error) {
+		blind, err := receiverAs[*Blind](kind, receiver)
+		if err != nil {
+			return nil, err
+		}
+		if len(args) != 0 {
+			return nil, fmt.Errorf("%s takes no arguments, got %d", kind, len(args))
+		}
+		return NewSetPositionCommand(blind, position), nil
+	}
+}
+
+func receiverAs[T any](kind string, receiver any) (T, error) {
+	typed, ok := receiver.(T)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("%s needs a %T receiver, got %T", kind, zero, receiver)
+	}
+	return typed, nil
+}
+
+func intArg(kind string, args []string) (int, error) {
+	if len(args) != 1 {
+		return 0, fmt.Errorf("%s expects 1 argument, got %d", kind, len(args))
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid number %q", kind, args[0])
+	}
+	return n, nil
+}
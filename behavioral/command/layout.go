@@ -0,0 +1,153 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// MacroKind is the command kind that groups nested commands into a MacroCommand.
+const MacroKind = "macro"
+
+// CommandSpec describes a command by name so it can be stored outside the program.
+type CommandSpec struct {
+	Kind     string        `json:"kind"`
+	Receiver string        `json:"receiver,omitempty"`
+	Args     []string      `json:"args,omitempty"`     // Parameters such as a dimmer level
+	Commands []CommandSpec `json:"commands,omitempty"` // Only used by MacroKind
+}
+
+// SlotSpec describes one named slot of a ProgrammableRemote.
+type SlotSpec struct {
+	Name string       `json:"name"`
+	On   *CommandSpec `json:"on,omitempty"`
+	Off  *CommandSpec `json:"off,omitempty"`
+}
+
+// Layout is the serializable slot configuration of a ProgrammableRemote.
+type Layout struct {
+	Slots []SlotSpec `json:"slots"`
+}
+
+// CommandFactory creates a command for the given receiver and arguments.
+type CommandFactory func(receiver any, args []string) (Command, error)
+
+// Registry resolves the names used in a Layout to live receivers and command kinds.
+type Registry struct {
+	receivers map[string]any
+	kinds     map[string]CommandFactory
+}
+
+// NewRegistry creates a registry that already knows the built-in command kinds
+// for every receiver in this package (see registerBuiltinKinds).
+func NewRegistry() *Registry {
+	reg := &Registry{
+		receivers: make(map[string]any),
+		kinds:     make(map[string]CommandFactory),
+	}
+	registerBuiltinKinds(reg)
+	return reg
+}
+
+func (reg *Registry) RegisterReceiver(name string, receiver any) {
+	reg.receivers[name] = receiver
+}
+
+func (reg *Registry) RegisterKind(kind string, factory CommandFactory) {
+	reg.kinds[kind] = factory
+}
+
+// Build turns a spec into a command, recursing into macros.
+func (reg *Registry) Build(spec CommandSpec) (Command, error) {
+	if spec.Kind == MacroKind {
+		commands := make([]Command, 0, len(spec.Commands))
+		for _, child := range spec.Commands {
+			cmd, err := reg.Build(child)
+			if err != nil {
+				return nil, err
+			}
+			commands = append(commands, cmd)
+		}
+		return NewMacroCommand(commands...), nil
+	}
+
+	factory, ok := reg.kinds[spec.Kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown command kind %q", spec.Kind)
+	}
+	receiver, ok := reg.receivers[spec.Receiver]
+	if !ok {
+		return nil, fmt.Errorf("unknown receiver %q for command kind %q", spec.Receiver, spec.Kind)
+	}
+	return factory(receiver, spec.Args)
+}
+
+// ApplyLayout replaces every slot of the remote with the ones described by layout.
+// The remote is left untouched if any command in the layout cannot be built.
+func (r *ProgrammableRemote) ApplyLayout(layout Layout, reg *Registry) error {
+	if len(layout.Slots) > r.slotCount {
+		return fmt.Errorf("layout has %d slots, remote only has %d", len(layout.Slots), r.slotCount)
+	}
+
+	slots := make([]*slot, 0, len(layout.Slots))
+	seen := make(map[string]bool)
+	for _, spec := range layout.Slots {
+		if seen[spec.Name] {
+			return fmt.Errorf("duplicate slot %q in layout", spec.Name)
+		}
+		seen[spec.Name] = true
+
+		s := &slot{name: spec.Name, onSpec: spec.On, offSpec: spec.Off}
+		var err error
+		if spec.On != nil {
+			if s.on, err = reg.Build(*spec.On); err != nil {
+				return fmt.Errorf("slot %q on: %w", spec.Name, err)
+			}
+		}
+		if spec.Off != nil {
+			if s.off, err = reg.Build(*spec.Off); err != nil {
+				return fmt.Errorf("slot %q off: %w", spec.Name, err)
+			}
+		}
+		slots = append(slots, s)
+	}
+
+	r.slots = slots
+	return nil
+}
+
+// Layout describes the current slots. Slots bound with SetSlot have no spec
+// and cannot be saved, so they are reported as an error.
+func (r *ProgrammableRemote) Layout() (Layout, error) {
+	layout := Layout{Slots: make([]SlotSpec, 0, len(r.slots))}
+	for _, s := range r.slots {
+		if (s.on != nil && s.onSpec == nil) || (s.off != nil && s.offSpec == nil) {
+			return Layout{}, fmt.Errorf("slot %q was not bound from a layout and cannot be saved", s.name)
+		}
+		layout.Slots = append(layout.Slots, SlotSpec{Name: s.name, On: s.onSpec, Off: s.offSpec})
+	}
+	return layout, nil
+}
+
+func LoadLayout(path string) (Layout, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Layout{}, fmt.Errorf("read layout: %w", err)
+	}
+	var layout Layout
+	if err := json.Unmarshal(data, &layout); err != nil {
+		return Layout{}, fmt.Errorf("decode layout %s: %w", path, err)
+	}
+	return layout, nil
+}
+
+func SaveLayout(path string, layout Layout) error {
+	data, err := json.MarshalIndent(layout, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode layout: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write layout: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package command
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestRegistry() (*Registry, *Light, *DimmableLight) {
+	reg := NewRegistry()
+	porch, living := &Light{}, &DimmableLight{}
+	reg.RegisterReceiver("porch", porch)
+	reg.RegisterReceiver("living", living)
+	reg.RegisterReceiver("hall", &Thermostat{setpoint: 20, mode: ModeHeat})
+	return reg, porch, living
+}
+
+func TestRegistryBuild(t *testing.T) {
+	reg, _, _ := newTestRegistry()
+	tests := []struct {
+		name    string
+		spec    CommandSpec
+		wantErr string // Empty if the build succeeds
+	}{
+		{"light", CommandSpec{Kind: "light.on", Receiver: "porch"}, ""},
+		{"dimmer with level", CommandSpec{Kind: "dimmer.dim", Receiver: "living", Args: []string{"40"}}, ""},
+		{"macro", CommandSpec{Kind: MacroKind, Commands: []CommandSpec{
+			{Kind: "light.on", Receiver: "porch"}, {Kind: "thermostat.mode", Receiver: "hall", Args: []string{"COOL"}}}}, ""},
+		{"unknown kind", CommandSpec{Kind: "light.blink", Receiver: "porch"}, `unknown command kind "light.blink"`},
+		{"unknown kind in a macro", CommandSpec{Kind: MacroKind, Commands: []CommandSpec{
+			{Kind: "light.on", Receiver: "porch"}, {Kind: "fan.on", Receiver: "porch"}}}, `unknown command kind "fan.on"`},
+		{"unknown receiver", CommandSpec{Kind: "light.on", Receiver: "garage"}, `unknown receiver "garage"`},
+		{"wrong receiver type", CommandSpec{Kind: "light.on", Receiver: "living"}, "needs a *command.Light receiver"},
+		{"missing argument", CommandSpec{Kind: "dimmer.dim", Receiver: "living"}, "expects 1 argument"},
+		{"bad argument", CommandSpec{Kind: "thermostat.set", Receiver: "hall", Args: []string{"warm"}}, "invalid temperature"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := reg.Build(tt.spec)
+			if tt.wantErr == "" {
+				if err != nil || cmd == nil {
+					t.Errorf("Build = %v, %v", cmd, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Build error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyLayout(t *testing.T) {
+	reg, porch, living := newTestRegistry()
+	onSpec := func(kind, receiver string, args ...string) *CommandSpec {
+		return &CommandSpec{Kind: kind, Receiver: receiver, Args: args}
+	}
+	good := Layout{Slots: []SlotSpec{
+		{Name: "porch", On: onSpec("light.on", "porch"), Off: onSpec("light.off", "porch")},
+		{Name: "movie", On: &CommandSpec{Kind: MacroKind, Commands: []CommandSpec{
+			{Kind: "light.off", Receiver: "porch"}, {Kind: "dimmer.dim", Receiver: "living", Args: []string{"20"}}}}},
+	}}
+	tests := []struct {
+		name      string
+		slotCount int
+		layout    Layout
+		wantSlots []string
+		wantErr   string
+	}{
+		{"applies", 3, good, []string{"porch", "movie"}, ""},
+		{"too many slots", 1, good, []string{"old"}, "layout has 2 slots, remote only has 1"},
+		{"duplicate slot", 3, Layout{Slots: []SlotSpec{{Name: "a"}, {Name: "a"}}}, []string{"old"}, `duplicate slot "a"`},
+		{"bad command leaves the remote untouched", 3, Layout{Slots: []SlotSpec{
+			{Name: "porch", On: onSpec("light.on", "porch")}, {Name: "bad", Off: onSpec("light.on", "nowhere")}}},
+			[]string{"old"}, `slot "bad" off: unknown receiver "nowhere"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remote := NewProgrammableRemote(tt.slotCount, 10)
+			if err := remote.SetSlot("old", NewTurnOnCommand(&Light{}), nil); err != nil {
+				t.Fatal(err)
+			}
+			err := remote.ApplyLayout(tt.layout, reg)
+			if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("ApplyLayout = %v, want %q", err, tt.wantErr)
+			}
+			if got := remote.Slots(); strings.Join(got, ",") != strings.Join(tt.wantSlots, ",") {
+				t.Errorf("slots = %v, want %v", got, tt.wantSlots)
+			}
+		})
+	}
+
+	remote := NewProgrammableRemote(3, 10)
+	if err := remote.ApplyLayout(good, reg); err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.PressOn("porch"); err != nil || !porch.IsOn() {
+		t.Fatalf("porch on: %v, light on = %t", err, porch.IsOn())
+	}
+	if err := remote.PressOn("movie"); err != nil || porch.IsOn() || living.Level() != 20 {
+		t.Fatalf("movie: %v, light on = %t, level = %d", err, porch.IsOn(), living.Level())
+	}
+	if err := remote.PressOff("movie"); err != nil {
+		t.Errorf("unassigned button: %v", err)
+	}
+	if err := remote.PressOn("garage"); err == nil {
+		t.Error("pressing an unknown slot succeeded")
+	}
+	// One shared history: undo reverts the movie macro, then the porch light.
+	for _, want := range []struct {
+		on    bool
+		level int
+	}{{true, 0}, {false, 0}} {
+		if err := remote.PressUndo(); err != nil {
+			t.Fatal(err)
+		}
+		if porch.IsOn() != want.on || living.Level() != want.level {
+			t.Errorf("after undo light on = %t, level = %d; want %t, %d", porch.IsOn(), living.Level(), want.on, want.level)
+		}
+	}
+}
+
+func TestLayoutRoundTrip(t *testing.T) {
+	reg, _, _ := newTestRegistry()
+	layout := Layout{Slots: []SlotSpec{
+		{Name: "porch", On: &CommandSpec{Kind: "light.on", Receiver: "porch"}},
+		{Name: "evening", On: &CommandSpec{Kind: MacroKind, Commands: []CommandSpec{
+			{Kind: "dimmer.dim", Receiver: "living", Args: []string{"30"}},
+			{Kind: "thermostat.set", Receiver: "hall", Args: []string{"21.5"}}}},
+			Off: &CommandSpec{Kind: "dimmer.off", Receiver: "living"}},
+	}}
+	remote := NewProgrammableRemote(2, 10)
+	if err := remote.ApplyLayout(layout, reg); err != nil {
+		t.Fatal(err)
+	}
+	current, err := remote.Layout()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "layout.json")
+	if err := SaveLayout(path, current); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadLayout(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	copyRemote := NewProgrammableRemote(2, 10)
+	if err := copyRemote.ApplyLayout(loaded, reg); err != nil {
+		t.Fatal(err)
+	}
+	again, err := copyRemote.Layout()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := specString(again), specString(layout); got != want {
+		t.Errorf("round trip gave\n%s\nwant\n%s", got, want)
+	}
+
+	if err := remote.SetSlot("porch", NewTurnOnCommand(&Light{}), nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := remote.Layout(); err == nil {
+		t.Error("Layout of a slot bound with SetSlot succeeded")
+	}
+	if _, err := LoadLayout(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadLayout of a missing file succeeded")
+	}
+}
+
+func specString(layout Layout) string {
+	var b strings.Builder
+	var write func(spec *CommandSpec)
+	write = func(spec *CommandSpec) {
+		if spec == nil {
+			b.WriteString("-")
+			return
+		}
+		b.WriteString(spec.Kind + "(" + spec.Receiver + " " + strings.Join(spec.Args, " "))
+		for i := range spec.Commands {
+			b.WriteString(" ")
+			write(&spec.Commands[i])
+		}
+		b.WriteString(")")
+	}
+	for _, s := range layout.Slots {
+		b.WriteString(s.Name + ": ")
+		write(s.On)
+		b.WriteString(" / ")
+		write(s.Off)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
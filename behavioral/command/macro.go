@@ -0,0 +1,45 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MacroCommand runs a sequence of commands as one unit.
+// It is itself a Command, so a macro can be bound to a button or nested in another macro.
+type MacroCommand struct {
+	commands []Command
+}
+
+func NewMacroCommand(commands ...Command) *MacroCommand {
+	return &MacroCommand{commands: commands}
+}
+
+// Execute stops at the first failing command. Commands that already ran are not reverted.
+func (m *MacroCommand) Execute() error {
+	for i, cmd := range m.commands {
+		if err := cmd.Execute(); err != nil {
+			return fmt.Errorf("macro step %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Undo reverts the commands in reverse order, so each one sees the state it produced.
+// A failing Undo does not stop the others; all failures are joined.
+func (m *MacroCommand) Undo() error {
+	var errs []error
+	for i := len(m.commands) - 1; i >= 0; i-- {
+		if err := m.commands[i].Undo(); err != nil {
+			errs = append(errs, fmt.Errorf("macro step %d undo: %w", i+1, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Forget passes Forget on to the commands of the macro.
+func (m *MacroCommand) Forget() {
+	for _, cmd := range m.commands {
+		forget(cmd)
+	}
+}
@@ -0,0 +1,108 @@
+package command
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// stepCommand logs its Execute and Undo calls and can be made to fail either.
+type stepCommand struct {
+	name               string
+	failExec, failUndo bool
+	log                *[]string
+}
+
+func (c *stepCommand) Execute() error {
+	*c.log = append(*c.log, c.name)
+	if c.failExec {
+		return errors.New(c.name + " failed")
+	}
+	return nil
+}
+
+func (c *stepCommand) Undo() error {
+	*c.log = append(*c.log, "undo "+c.name)
+	if c.failUndo {
+		return errors.New(c.name + " undo failed")
+	}
+	return nil
+}
+
+func TestMacroCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		failExec    string // Step whose Execute fails
+		failUndo    []string
+		wantExec    []string
+		wantExecErr string
+		wantUndo    []string
+		wantUndoErr []string
+	}{
+		{"all steps", "", nil, []string{"a", "b", "c"}, "", []string{"undo c", "undo b", "undo a"}, nil},
+		{"stops at the failing step", "b", nil, []string{"a", "b"}, "macro step 2: b failed", nil, nil},
+		{"undo failures are joined", "", []string{"a", "c"}, []string{"a", "b", "c"}, "",
+			[]string{"undo c", "undo b", "undo a"}, []string{"macro step 3 undo: c undo failed", "macro step 1 undo: a undo failed"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var log []string
+			var steps []Command
+			for _, name := range []string{"a", "b", "c"} {
+				steps = append(steps, &stepCommand{name: name, failExec: name == tt.failExec,
+					failUndo: slices.Contains(tt.failUndo, name), log: &log})
+			}
+			macro := NewMacroCommand(steps...)
+			err := macro.Execute()
+			if !slices.Equal(log, tt.wantExec) {
+				t.Errorf("Execute ran %v, want %v", log, tt.wantExec)
+			}
+			if tt.wantExecErr != "" {
+				if err == nil || err.Error() != tt.wantExecErr {
+					t.Errorf("Execute = %v, want %q", err, tt.wantExecErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			log = nil
+			err = macro.Undo()
+			if !slices.Equal(log, tt.wantUndo) {
+				t.Errorf("Undo ran %v, want %v", log, tt.wantUndo)
+			}
+			if got := errorLines(err); !slices.Equal(got, tt.wantUndoErr) {
+				t.Errorf("Undo errors = %q, want %q", got, tt.wantUndoErr)
+			}
+		})
+	}
+}
+
+func TestNestedMacroUndo(t *testing.T) {
+	light, dimmer := &Light{}, &DimmableLight{}
+	evening := NewMacroCommand(
+		NewTurnOnCommand(light),
+		NewMacroCommand(NewSetLevelCommand(dimmer, 30), NewSetLevelCommand(dimmer, 60)),
+	)
+	if err := evening.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if !light.IsOn() || dimmer.Level() != 60 {
+		t.Fatalf("after Execute light on = %t, level = %d", light.IsOn(), dimmer.Level())
+	}
+	if err := evening.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if light.IsOn() || dimmer.Level() != 0 {
+		t.Errorf("after Undo light on = %t, level = %d; want off and 0", light.IsOn(), dimmer.Level())
+	}
+}
+
+// errorLines splits a (joined) error into its lines; nil gives nil.
+func errorLines(err error) []string {
+	if err == nil {
+		return nil
+	}
+	return strings.Split(err.Error(), "\n")
+}
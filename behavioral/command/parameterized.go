@@ -0,0 +1,133 @@
+package command
+
+// Parameterized commands capture their argument when created and push the value they
+// replace on every Execute, so Undo restores exactly what was there before. Undo goes
+// through the receiver's setter like Execute does, so it is validated and reported the
+// same way. Thermostats should come from NewThermostat, which validates its arguments:
+// the zero value has no valid setpoint or mode to go back to.
+
+// SetLevelCommand dims a DimmableLight to a fixed level.
+type SetLevelCommand struct {
+	dimmer     *DimmableLight
+	level      int
+	prevLevels []int
+}
+
+func NewSetLevelCommand(dimmer *DimmableLight, level int) *SetLevelCommand {
+	return &SetLevelCommand{dimmer: dimmer, level: level}
+}
+
+func (c *SetLevelCommand) Execute() error {
+	prev := c.dimmer.level
+	if err := c.dimmer.SetLevel(c.level); err != nil {
+		return err
+	}
+	c.prevLevels = append(c.prevLevels, prev)
+	return nil
+}
+
+func (c *SetLevelCommand) Undo() error {
+	return undoTo(&c.prevLevels, c.dimmer.SetLevel)
+}
+
+func (c *SetLevelCommand) Forget() {
+	forgetOldest(&c.prevLevels)
+}
+
+// SetSetpointCommand changes a Thermostat's setpoint.
+type SetSetpointCommand struct {
+	thermostat *Thermostat
+	setpoint   float64
+	prevPoints []float64
+}
+
+func NewSetSetpointCommand(thermostat *Thermostat, celsius float64) *SetSetpointCommand {
+	return &SetSetpointCommand{thermostat: thermostat, setpoint: celsius}
+}
+
+func (c *SetSetpointCommand) Execute() error {
+	prev := c.thermostat.setpoint
+	if err := c.thermostat.SetSetpoint(c.setpoint); err != nil {
+		return err
+	}
+	c.prevPoints = append(c.prevPoints, prev)
+	return nil
+}
+
+func (c *SetSetpointCommand) Undo() error {
+	return undoTo(&c.prevPoints, c.thermostat.SetSetpoint)
+}
+
+func (c *SetSetpointCommand) Forget() {
+	forgetOldest(&c.prevPoints)
+}
+
+// SetModeCommand switches a Thermostat's operating mode.
+type SetModeCommand struct {
+	thermostat *Thermostat
+	mode       ThermostatMode
+	prevModes  []ThermostatMode
+}
+
+func NewSetModeCommand(thermostat *Thermostat, mode ThermostatMode) *SetModeCommand {
+	return &SetModeCommand{thermostat: thermostat, mode: mode}
+}
+
+func (c *SetModeCommand) Execute() error {
+	prev := c.thermostat.mode
+	if err := c.thermostat.SetMode(c.mode); err != nil {
+		return err
+	}
+	c.prevModes = append(c.prevModes, prev)
+	return nil
+}
+
+func (c *SetModeCommand) Undo() error {
+	return undoTo(&c.prevModes, c.thermostat.SetMode)
+}
+
+func (c *SetModeCommand) Forget() {
+	forgetOldest(&c.prevModes)
+}
+
+// SetPositionCommand moves a Blind to a fixed position.
+type SetPositionCommand struct {
+	blind         *Blind
+	position      int
+	prevPositions []int
+}
+
+func NewSetPositionCommand(blind *Blind, position int) *SetPositionCommand {
+	return &SetPositionCommand{blind: blind, position: position}
+}
+
+func (c *SetPositionCommand) Execute() error {
+	prev := c.blind.position
+	if err := c.blind.SetPosition(c.position); err != nil {
+		return err
+	}
+	c.prevPositions = append(c.prevPositions, prev)
+	return nil
+}
+
+func (c *SetPositionCommand) Undo() error {
+	return undoTo(&c.prevPositions, c.blind.SetPosition)
+}
+
+func (c *SetPositionCommand) Forget() {
+	forgetOldest(&c.prevPositions)
+}
+
+// undoTo restores the most recently saved value through set. The value is dropped
+// only once set accepts it, so a failed Undo leaves history and command in step and
+// can be retried.
+func undoTo[T any](stack *[]T, set func(T) error) error {
+	if len(*stack) == 0 {
+		return ErrNothingToUndo
+	}
+	if err := set((*stack)[len(*stack)-1]); err != nil {
+		return err
+	}
+	*stack = (*stack)[:len(*stack)-1]
+	return nil
+}
@@ -0,0 +1,129 @@
+package command
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParameterizedCommands(t *testing.T) {
+	dimmer := &DimmableLight{}
+	thermostat, err := NewThermostat(20, ModeHeat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blind := &Blind{}
+	state := func() []any {
+		return []any{dimmer.Level(), thermostat.Setpoint(), thermostat.Mode(), blind.Position()}
+	}
+
+	tests := []struct {
+		name      string
+		cmd       Command
+		wantErr   bool
+		afterExec []any
+		afterUndo []any
+	}{
+		{"dim", NewSetLevelCommand(dimmer, 40), false, []any{40, 20.0, ModeHeat, 0}, []any{0, 20.0, ModeHeat, 0}},
+		{"dim out of range", NewSetLevelCommand(dimmer, 140), true, []any{0, 20.0, ModeHeat, 0}, nil},
+		{"setpoint", NewSetSetpointCommand(thermostat, 21.5), false, []any{0, 21.5, ModeHeat, 0}, []any{0, 20.0, ModeHeat, 0}},
+		{"setpoint too cold", NewSetSetpointCommand(thermostat, 2), true, []any{0, 20.0, ModeHeat, 0}, nil},
+		{"mode", NewSetModeCommand(thermostat, ModeCool), false, []any{0, 20.0, ModeCool, 0}, []any{0, 20.0, ModeHeat, 0}},
+		{"unknown mode", NewSetModeCommand(thermostat, "turbo"), true, []any{0, 20.0, ModeHeat, 0}, nil},
+		{"blind", NewSetPositionCommand(blind, 75), false, []any{0, 20.0, ModeHeat, 75}, []any{0, 20.0, ModeHeat, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cmd.Execute()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Execute error = %v, want error %t", err, tt.wantErr)
+			}
+			if got := state(); !equal(got, tt.afterExec) {
+				t.Errorf("after Execute state = %v, want %v", got, tt.afterExec)
+			}
+			if tt.wantErr {
+				if err := tt.cmd.Undo(); err != ErrNothingToUndo {
+					t.Errorf("Undo of a failed Execute = %v, want ErrNothingToUndo", err)
+				}
+				return
+			}
+			if err := tt.cmd.Undo(); err != nil {
+				t.Fatal(err)
+			}
+			if got := state(); !equal(got, tt.afterUndo) {
+				t.Errorf("after Undo state = %v, want %v", got, tt.afterUndo)
+			}
+		})
+	}
+}
+
+func TestUndoOnZeroThermostatIsValidated(t *testing.T) {
+	cmd := NewSetSetpointCommand(&Thermostat{}, 21)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Undo(); err == nil {
+		t.Error("Undo restored the zero value's invalid 0°C setpoint without an error")
+	}
+}
+
+func TestFailedUndoKeepsHistory(t *testing.T) {
+	thermostat := &Thermostat{}
+	cmd := NewSetSetpointCommand(thermostat, 21)
+	for range 2 {
+		if err := cmd.Execute(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := cmd.Undo(); err != nil || thermostat.Setpoint() != 21 {
+		t.Fatalf("first Undo = %v, setpoint %v; want nil, 21", err, thermostat.Setpoint())
+	}
+	// The saved 0°C is invalid. It must stay saved, so every retry fails the same way
+	// instead of reporting that there is nothing left to undo.
+	for range 2 {
+		if err := cmd.Undo(); err == nil || errors.Is(err, ErrNothingToUndo) {
+			t.Errorf("Undo to 0°C = %v, want a validation error", err)
+		}
+	}
+	if thermostat.Setpoint() != 21 || len(cmd.prevPoints) != 1 {
+		t.Errorf("after failed undos setpoint = %v with %d saved values; want 21 with 1", thermostat.Setpoint(), len(cmd.prevPoints))
+	}
+}
+
+func TestNewThermostat(t *testing.T) {
+	tests := []struct {
+		name     string
+		setpoint float64
+		mode     ThermostatMode
+		wantErr  bool
+	}{
+		{"valid", 20, ModeHeat, false},
+		{"bounds", MinSetpoint, ModeOff, false},
+		{"setpoint too low", 0, ModeHeat, true},
+		{"setpoint too high", 40, ModeCool, true},
+		{"unknown mode", 20, "eco", true},
+		{"empty mode", 20, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thermostat, err := NewThermostat(tt.setpoint, tt.mode)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewThermostat = %v, wantErr %t", err, tt.wantErr)
+			}
+			if err == nil && (thermostat.Setpoint() != tt.setpoint || thermostat.Mode() != tt.mode) {
+				t.Errorf("thermostat = %v %v, want %v %v", thermostat.Setpoint(), thermostat.Mode(), tt.setpoint, tt.mode)
+			}
+		})
+	}
+}
+
+func equal(a, b []any) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package command
+
+import "fmt"
+
+// slot is a named button pair on a ProgrammableRemote.
+// The specs are kept when the slot was bound from a Layout, so it can be saved again.
+type slot struct {
+	name    string
+	on      Command
+	off     Command
+	onSpec  *CommandSpec
+	offSpec *CommandSpec
+}
+
+// ProgrammableRemote is an invoker with a fixed number of named slots,
+// each holding an on/off pair of commands. All slots share one undo/redo history.
+type ProgrammableRemote struct {
+	slotCount int
+	slots     []*slot // Kept in binding order so layouts round-trip predictably
+	history   *History
+}
+
+func NewProgrammableRemote(slotCount, historyLimit int) *ProgrammableRemote {
+	return &ProgrammableRemote{
+		slotCount: slotCount,
+		history:   NewHistory(historyLimit),
+	}
+}
+
+// SetSlot binds an on/off pair to the named slot, replacing any previous binding.
+// Either command may be nil, leaving that button unassigned.
+func (r *ProgrammableRemote) SetSlot(name string, on, off Command) error {
+	return r.setSlot(&slot{name: name, on: on, off: off})
+}
+
+func (r *ProgrammableRemote) setSlot(s *slot) error {
+	for i, existing := range r.slots {
+		if existing.name == s.name {
+			r.slots[i] = s
+			return nil
+		}
+	}
+	if len(r.slots) >= r.slotCount {
+		return fmt.Errorf("remote has no free slot for %q (%d slots)", s.name, r.slotCount)
+	}
+	r.slots = append(r.slots, s)
+	return nil
+}
+
+// Slots returns the names of the bound slots in binding order.
+func (r *ProgrammableRemote) Slots() []string {
+	names := make([]string, 0, len(r.slots))
+	for _, s := range r.slots {
+		names = append(names, s.name)
+	}
+	return names
+}
+
+func (r *ProgrammableRemote) PressOn(name string) error {
+	s, err := r.slot(name)
+	if err != nil {
+		return err
+	}
+	return r.press(s.name, "ON", s.on)
+}
+
+func (r *ProgrammableRemote) PressOff(name string) error {
+	s, err := r.slot(name)
+	if err != nil {
+		return err
+	}
+	return r.press(s.name, "OFF", s.off)
+}
+
+func (r *ProgrammableRemote) PressUndo() error {
+	cmd, ok := r.history.Undo()
+	if !ok {
+		fmt.Println("ProgrammableRemote: Nothing to undo.")
+		return nil
+	}
+	fmt.Print("ProgrammableRemote: Undo pressed. ")
+	if err := cmd.Undo(); err != nil {
+		r.history.Redo() // The command is still in effect, keep it undoable
+		return fmt.Errorf("undo: %w", err)
+	}
+	return nil
+}
+
+func (r *ProgrammableRemote) PressRedo() error {
+	cmd, ok := r.history.Redo()
+	if !ok {
+		fmt.Println("ProgrammableRemote: Nothing to redo.")
+		return nil
+	}
+	fmt.Print("ProgrammableRemote: Redo pressed. ")
+	if err := cmd.Execute(); err != nil {
+		r.history.Undo() // The command did not take effect, keep it redoable
+		return fmt.Errorf("redo: %w", err)
+	}
+	return nil
+}
+
+func (r *ProgrammableRemote) press(name, button string, cmd Command) error {
+	if cmd == nil {
+		fmt.Printf("ProgrammableRemote: No command set for %s %s.\n", name, button)
+		return nil
+	}
+	fmt.Printf("ProgrammableRemote: %s %s pressed. ", name, button)
+	if err := cmd.Execute(); err != nil {
+		return fmt.Errorf("%s %s: %w", name, button, err)
+	}
+	r.history.Push(cmd)
+	return nil
+}
+
+func (r *ProgrammableRemote) slot(name string) (*slot, error) {
+	for _, s := range r.slots {
+		if s.name == name {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown slot %q", name)
+}
@@ -0,0 +1,225 @@
+package command
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrQueueClosed is returned when submitting to a CommandQueue that is shutting down.
+var ErrQueueClosed = errors.New("command queue is closed")
+
+// QueueConfig tunes a CommandQueue. Zero values fall back to sensible defaults.
+type QueueConfig struct {
+	Workers     int           // Number of goroutines executing commands (default 1); see CommandQueue
+	MaxRetries  int           // Extra attempts after a failed Execute (default 0)
+	BaseBackoff time.Duration // Delay before the first retry, doubled on each retry (default 10ms)
+	MaxBackoff  time.Duration // Upper bound for the retry delay (default 1s)
+}
+
+// Result is the outcome of a queued command.
+type Result struct {
+	Command  Command
+	Attempts int
+	Err      error // Last Execute error, or the context error if the command was cancelled
+}
+
+// Future delivers the Result of a queued command once it is known.
+type Future struct {
+	done   chan struct{}
+	result Result
+}
+
+// Done is closed when the result is available.
+func (f *Future) Done() <-chan struct{} {
+	return f.done
+}
+
+// Wait blocks until the command has finished and returns its result.
+func (f *Future) Wait() Result {
+	<-f.done
+	return f.result
+}
+
+// CommandQueue is an asynchronous invoker. Commands are executed by a pool of workers,
+// highest priority first and in submission order within the same priority.
+//
+// The receivers and commands in this package are not safe for concurrent use. With more
+// than one worker, commands that share a receiver (or the same command submitted twice)
+// may run at the same time, so either keep Workers at 1 or give each worker's commands
+// their own receivers.
+type CommandQueue struct {
+	cfg QueueConfig
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	pending queueHeap
+	seq     uint64
+	closed  bool
+
+	ctx     context.Context // Cancelled when a shutdown gives up on draining
+	cancel  context.CancelFunc
+	workers sync.WaitGroup
+}
+
+func NewCommandQueue(cfg QueueConfig) *CommandQueue {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = 10 * time.Millisecond
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = time.Second
+	}
+
+	q := &CommandQueue{cfg: cfg}
+	q.cond = sync.NewCond(&q.mu)
+	q.ctx, q.cancel = context.WithCancel(context.Background())
+	for i := 0; i < cfg.Workers; i++ {
+		q.workers.Add(1)
+		go q.work()
+	}
+	return q
+}
+
+// Submit queues cmd for execution. Higher priorities run first. If ctx is cancelled
+// before the command succeeds, it is not (re)tried and its result carries ctx.Err().
+// A nil ctx is treated as context.Background().
+func (q *CommandQueue) Submit(ctx context.Context, cmd Command, priority int) (*Future, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return nil, ErrQueueClosed
+	}
+	q.seq++
+	item := &queueItem{
+		ctx:      ctx,
+		cmd:      cmd,
+		priority: priority,
+		seq:      q.seq,
+		future:   &Future{done: make(chan struct{})},
+	}
+	heap.Push(&q.pending, item)
+	q.cond.Signal()
+	return item.future, nil
+}
+
+// Shutdown stops accepting commands and waits for pending ones to drain.
+// If ctx ends first, the remaining commands are cancelled and ctx.Err() is returned;
+// a command already inside Execute is still allowed to finish.
+func (q *CommandQueue) Shutdown(ctx context.Context) error {
+	q.mu.Lock()
+	q.closed = true
+	q.cond.Broadcast()
+	q.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		q.workers.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		q.cancel()
+		return nil
+	case <-ctx.Done():
+		q.cancel()
+		<-drained
+		return ctx.Err()
+	}
+}
+
+func (q *CommandQueue) work() {
+	defer q.workers.Done()
+	for {
+		q.mu.Lock()
+		for len(q.pending) == 0 && !q.closed {
+			q.cond.Wait()
+		}
+		if len(q.pending) == 0 {
+			q.mu.Unlock()
+			return
+		}
+		item := heap.Pop(&q.pending).(*queueItem)
+		q.mu.Unlock()
+
+		item.future.result = q.run(item)
+		close(item.future.done)
+	}
+}
+
+func (q *CommandQueue) run(item *queueItem) Result {
+	res := Result{Command: item.cmd}
+	backoff := q.cfg.BaseBackoff
+	for {
+		if err := q.cancelled(item.ctx); err != nil {
+			res.Err = err
+			return res
+		}
+
+		res.Attempts++
+		res.Err = item.cmd.Execute()
+		if res.Err == nil || res.Attempts > q.cfg.MaxRetries {
+			return res
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-item.ctx.Done():
+			timer.Stop()
+		case <-q.ctx.Done():
+			timer.Stop()
+		}
+		backoff = min(backoff*2, q.cfg.MaxBackoff)
+	}
+}
+
+func (q *CommandQueue) cancelled(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return q.ctx.Err()
+}
+
+type queueItem struct {
+	ctx      context.Context
+	cmd      Command
+	priority int
+	seq      uint64
+	future   *Future
+}
+
+// queueHeap orders items by priority (highest first), then by submission order.
+type queueHeap []*queueItem
+
+func (h queueHeap) Len() int { return len(h) }
+
+func (h queueHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h queueHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *queueHeap) Push(x any) { *h = append(*h, x.(*queueItem)) }
+
+func (h *queueHeap) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,119 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordCommand appends its name to a shared log and fails the first failures times.
+type recordCommand struct {
+	name     string
+	failures int
+	mu       *sync.Mutex
+	log      *[]string
+}
+
+func (c *recordCommand) Execute() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	*c.log = append(*c.log, c.name)
+	if c.failures > 0 {
+		c.failures--
+		return errors.New("not yet")
+	}
+	return nil
+}
+
+func (c *recordCommand) Undo() error { return nil }
+
+func TestCommandQueue(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxRetries   int
+		failures     int
+		ctx          context.Context
+		wantAttempts int
+		wantErr      bool
+	}{
+		{"succeeds", 0, 0, context.Background(), 1, false},
+		{"nil context", 0, 0, nil, 1, false},
+		{"retries until success", 3, 2, context.Background(), 3, false},
+		{"gives up after retries", 1, 5, context.Background(), 2, true},
+		{"cancelled before running", 3, 0, cancelledContext(), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := NewCommandQueue(QueueConfig{MaxRetries: tt.maxRetries, BaseBackoff: time.Millisecond})
+			defer q.Shutdown(context.Background())
+			var mu sync.Mutex
+			var log []string
+			f, err := q.Submit(tt.ctx, &recordCommand{name: "cmd", failures: tt.failures, mu: &mu, log: &log}, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res := f.Wait()
+			if res.Attempts != tt.wantAttempts || (res.Err != nil) != tt.wantErr {
+				t.Errorf("attempts = %d, err = %v; want %d attempts, error %t", res.Attempts, res.Err, tt.wantAttempts, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommandQueuePriority(t *testing.T) {
+	q := NewCommandQueue(QueueConfig{})
+	var mu sync.Mutex
+	var log []string
+
+	// Hold the single worker so the rest queue up behind it.
+	release := make(chan struct{})
+	blocker, _ := q.Submit(context.Background(), blockingCommand(release), 0)
+	var futures []*Future
+	for _, c := range []struct {
+		name     string
+		priority int
+	}{{"low", 0}, {"high", 10}, {"mid", 5}, {"high2", 10}} {
+		f, err := q.Submit(context.Background(), &recordCommand{name: c.name, mu: &mu, log: &log}, c.priority)
+		if err != nil {
+			t.Fatal(err)
+		}
+		futures = append(futures, f)
+	}
+	close(release)
+	blocker.Wait()
+	for _, f := range futures {
+		f.Wait()
+	}
+	if err := q.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"high", "high2", "mid", "low"}
+	if len(log) != len(want) {
+		t.Fatalf("ran %v, want %v", log, want)
+	}
+	for i := range want {
+		if log[i] != want[i] {
+			t.Fatalf("ran %v, want %v", log, want)
+		}
+	}
+	if _, err := q.Submit(context.Background(), blockingCommand(nil), 0); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Submit after Shutdown = %v, want ErrQueueClosed", err)
+	}
+}
+
+type blockingCommand chan struct{}
+
+func (c blockingCommand) Execute() error {
+	<-c
+	return nil
+}
+
+func (c blockingCommand) Undo() error { return nil }
+
+func cancelledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
@@ -0,0 +1,156 @@
+package command
+
+import "fmt"
+
+// Receivers beyond the plain Light. Each one validates its own parameters, so
+// commands only need to capture arguments and the values they replace.
+
+// DimmableLight is a light with a brightness level between 0 and 100.
+type DimmableLight struct {
+	level int
+}
+
+func (d *DimmableLight) DeviceType() string {
+	return "dimmer"
+}
+
+func (d *DimmableLight) SetLevel(level int) error {
+	if level < 0 || level > 100 {
+		return fmt.Errorf("dimmer level %d out of range 0-100", level)
+	}
+	d.level = level
+	fmt.Printf("Dimmer set to %d%%\n", level)
+	return nil
+}
+
+func (d *DimmableLight) Level() int {
+	return d.level
+}
+
+// DimmerState is the JSON view of a DimmableLight.
+type DimmerState struct {
+	Level int `json:"level"`
+}
+
+func (d *DimmableLight) State() any {
+	return DimmerState{Level: d.level}
+}
+
+// ThermostatMode selects how a Thermostat works towards its setpoint.
+type ThermostatMode string
+
+const (
+	ModeOff  ThermostatMode = "off"
+	ModeHeat ThermostatMode = "heat"
+	ModeCool ThermostatMode = "cool"
+	ModeAuto ThermostatMode = "auto"
+)
+
+const (
+	MinSetpoint = 5.0  // °C
+	MaxSetpoint = 35.0 // °C
+)
+
+// Thermostat holds a temperature setpoint in °C and an operating mode.
+type Thermostat struct {
+	setpoint float64
+	mode     ThermostatMode
+}
+
+// NewThermostat returns a Thermostat with a valid setpoint and mode. The zero value
+// has neither, so parameterized commands cannot undo back to it.
+func NewThermostat(setpoint float64, mode ThermostatMode) (*Thermostat, error) {
+	if err := checkSetpoint(setpoint); err != nil {
+		return nil, err
+	}
+	if err := checkMode(mode); err != nil {
+		return nil, err
+	}
+	return &Thermostat{setpoint: setpoint, mode: mode}, nil
+}
+
+func (t *Thermostat) DeviceType() string {
+	return "thermostat"
+}
+
+func (t *Thermostat) SetSetpoint(celsius float64) error {
+	if err := checkSetpoint(celsius); err != nil {
+		return err
+	}
+	t.setpoint = celsius
+	fmt.Printf("Thermostat setpoint is %.1f°C\n", celsius)
+	return nil
+}
+
+func (t *Thermostat) SetMode(mode ThermostatMode) error {
+	if err := checkMode(mode); err != nil {
+		return err
+	}
+	t.mode = mode
+	fmt.Printf("Thermostat mode is %s\n", mode)
+	return nil
+}
+
+func checkSetpoint(celsius float64) error {
+	if celsius < MinSetpoint || celsius > MaxSetpoint {
+		return fmt.Errorf("setpoint %.1f°C out of range %.0f-%.0f", celsius, MinSetpoint, MaxSetpoint)
+	}
+	return nil
+}
+
+func checkMode(mode ThermostatMode) error {
+	switch mode {
+	case ModeOff, ModeHeat, ModeCool, ModeAuto:
+		return nil
+	}
+	return fmt.Errorf("unknown thermostat mode %q", mode)
+}
+
+func (t *Thermostat) Setpoint() float64 {
+	return t.setpoint
+}
+
+func (t *Thermostat) Mode() ThermostatMode {
+	return t.mode
+}
+
+// ThermostatState is the JSON view of a Thermostat.
+type ThermostatState struct {
+	Setpoint float64        `json:"setpoint"`
+	Mode     ThermostatMode `json:"mode"`
+}
+
+func (t *Thermostat) State() any {
+	return ThermostatState{Setpoint: t.setpoint, Mode: t.mode}
+}
+
+// Blind is a window blind whose position goes from 0 (closed) to 100 (open).
+type Blind struct {
+	position int
+}
+
+func (b *Blind) DeviceType() string {
+	return "blind"
+}
+
+func (b *Blind) SetPosition(position int) error {
+	if position < 0 || position > 100 {
+		return fmt.Errorf("blind position %d out of range 0-100", position)
+	}
+	b.position = position
+	fmt.Printf("Blind at %d%%\n", position)
+	return nil
+}
+
+func (b *Blind) Position() int {
+	return b.position
+}
+
+// BlindState is the JSON view of a Blind.
+type BlindState struct {
+	Position int `json:"position"`
+}
+
+func (b *Blind) State() any {
+	return BlindState{Position: b.position}
+}
@@ -0,0 +1,270 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrEntryNotFound is returned when a scheduled entry ID is unknown.
+var ErrEntryNotFound = errors.New("scheduled entry not found")
+
+// Schedule decides when a scheduled command runs next.
+type Schedule interface {
+	// Next returns the first activation strictly after t, or the zero time if there is none.
+	Next(t time.Time) time.Time
+	String() string
+}
+
+// onceSchedule fires a single time.
+type onceSchedule struct {
+	at time.Time
+}
+
+func (s onceSchedule) Next(t time.Time) time.Time {
+	if t.Before(s.at) {
+		return s.at
+	}
+	return time.Time{}
+}
+
+func (s onceSchedule) String() string {
+	return "once at " + s.at.Format(time.RFC3339)
+}
+
+// EntryInfo is a snapshot of a scheduled entry. A one-shot entry is listed with a
+// zero Next while its command runs and is removed once the command returns, so its
+// Runs and LastErr are never seen; wrap the command to observe its outcome.
+type EntryInfo struct {
+	ID       int
+	Schedule string
+	Next     time.Time
+	Paused   bool
+	Runs     int
+	LastErr  error
+}
+
+type scheduledEntry struct {
+	id       int
+	schedule Schedule
+	cmd      Command
+	next     time.Time
+	paused   bool
+	runs     int
+	lastErr  error
+}
+
+// Scheduler runs commands at an absolute time, after a delay or on a recurring schedule.
+// All time comes from the injected Clock, so tests can drive it with a FakeClock and
+// call RunDue directly instead of starting Run.
+type Scheduler struct {
+	clock Clock
+
+	mu      sync.Mutex
+	entries map[int]*scheduledEntry
+	nextID  int
+	wake    chan struct{}
+}
+
+func NewScheduler(clock Clock) *Scheduler {
+	return &Scheduler{
+		clock:   clock,
+		entries: make(map[int]*scheduledEntry),
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// ScheduleAt runs cmd once at t. A time in the past runs on the next RunDue.
+func (s *Scheduler) ScheduleAt(t time.Time, cmd Command) int {
+	return s.add(onceSchedule{at: t}, t, cmd)
+}
+
+// ScheduleAfter runs cmd once after d has elapsed on the scheduler's clock.
+func (s *Scheduler) ScheduleAfter(d time.Duration, cmd Command) int {
+	return s.ScheduleAt(s.clock.Now().Add(d), cmd)
+}
+
+// ScheduleCron runs cmd every time the cron spec matches, e.g. "0 7 * * mon-fri".
+func (s *Scheduler) ScheduleCron(spec string, cmd Command) (int, error) {
+	schedule, err := ParseCron(spec)
+	if err != nil {
+		return 0, err
+	}
+	return s.Schedule(schedule, cmd)
+}
+
+// Schedule runs cmd according to any Schedule implementation.
+func (s *Scheduler) Schedule(schedule Schedule, cmd Command) (int, error) {
+	next := schedule.Next(s.clock.Now())
+	if next.IsZero() {
+		return 0, fmt.Errorf("schedule %s never fires", schedule)
+	}
+	return s.add(schedule, next, cmd), nil
+}
+
+func (s *Scheduler) add(schedule Schedule, next time.Time, cmd Command) int {
+	s.mu.Lock()
+	s.nextID++
+	id := s.nextID
+	s.entries[id] = &scheduledEntry{id: id, schedule: schedule, cmd: cmd, next: next}
+	s.mu.Unlock()
+	s.notify()
+	return id
+}
+
+// Entries lists the scheduled entries ordered by their next run time.
+func (s *Scheduler) Entries() []EntryInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	infos := make([]EntryInfo, 0, len(s.entries))
+	for _, e := range s.entries {
+		infos = append(infos, EntryInfo{
+			ID:       e.id,
+			Schedule: e.schedule.String(),
+			Next:     e.next,
+			Paused:   e.paused,
+			Runs:     e.runs,
+			LastErr:  e.lastErr,
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		if !infos[i].Next.Equal(infos[j].Next) {
+			return infos[i].Next.Before(infos[j].Next)
+		}
+		return infos[i].ID < infos[j].ID
+	})
+	return infos
+}
+
+// Pause keeps the entry but skips it until Resume is called.
+func (s *Scheduler) Pause(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[id]
+	if !ok {
+		return fmt.Errorf("pause %d: %w", id, ErrEntryNotFound)
+	}
+	e.paused = true
+	return nil
+}
+
+// Resume re-enables a paused entry. Recurring entries do not catch up on
+// activations missed while paused; they continue from the current time. A one-shot
+// entry whose time passed while it was paused is still due, so it runs on the next
+// RunDue, just like one scheduled in the past with ScheduleAt.
+func (s *Scheduler) Resume(id int) error {
+	s.mu.Lock()
+	e, ok := s.entries[id]
+	if !ok {
+		s.mu.Unlock()
+		return fmt.Errorf("resume %d: %w", id, ErrEntryNotFound)
+	}
+	e.paused = false
+	if _, once := e.schedule.(onceSchedule); !once {
+		if now := s.clock.Now(); e.next.Before(now) {
+			e.next = e.schedule.Next(now)
+		}
+	}
+	s.mu.Unlock()
+	s.notify()
+	return nil
+}
+
+func (s *Scheduler) Remove(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.entries[id]; !ok {
+		return fmt.Errorf("remove %d: %w", id, ErrEntryNotFound)
+	}
+	delete(s.entries, id)
+	return nil
+}
+
+// RunDue executes every active entry whose time has come and returns how many ran.
+// Commands run outside the scheduler's lock, in order of their due time.
+func (s *Scheduler) RunDue() int {
+	now := s.clock.Now()
+
+	s.mu.Lock()
+	var due []*scheduledEntry
+	for _, e := range s.entries {
+		if !e.paused && !e.next.IsZero() && !e.next.After(now) {
+			due = append(due, e)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].next.Equal(due[j].next) {
+			return due[i].next.Before(due[j].next)
+		}
+		return due[i].id < due[j].id
+	})
+	for _, e := range due {
+		// Missed activations are not replayed: the next run is computed from now.
+		// A zero next time marks a finished one-shot, which stays listed until it has run.
+		e.next = e.schedule.Next(now)
+	}
+	s.mu.Unlock()
+
+	for _, e := range due {
+		err := e.cmd.Execute()
+		s.mu.Lock()
+		e.runs++
+		e.lastErr = err
+		if e.next.IsZero() {
+			delete(s.entries, e.id)
+		}
+		s.mu.Unlock()
+	}
+	return len(due)
+}
+
+// Run drives the scheduler from its clock until ctx is done.
+func (s *Scheduler) Run(ctx context.Context) error {
+	for {
+		s.RunDue()
+
+		var timer Timer
+		var fired <-chan time.Time
+		if next, ok := s.nextDue(); ok {
+			timer = s.clock.NewTimer(next.Sub(s.clock.Now()))
+			fired = timer.C()
+		}
+		select {
+		case <-ctx.Done():
+		case <-fired:
+		case <-s.wake:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Scheduler) nextDue() (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var next time.Time
+	for _, e := range s.entries {
+		if e.paused || e.next.IsZero() {
+			continue
+		}
+		if next.IsZero() || e.next.Before(next) {
+			next = e.next
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// notify wakes Run so it can recompute its timer after the entries changed.
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,163 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// Friday 5 January 2024, 06:00 UTC.
+var friday = time.Date(2024, time.January, 5, 6, 0, 0, 0, time.UTC)
+
+func TestSchedulerRunDue(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule func(s *Scheduler, cmd Command) error
+		advance  time.Duration // Stepped through one hour at a time
+		wantRuns int
+	}{
+		{"after delay", func(s *Scheduler, cmd Command) error {
+			s.ScheduleAfter(90*time.Minute, cmd)
+			return nil
+		}, 4 * time.Hour, 1},
+		{"at absolute time", func(s *Scheduler, cmd Command) error {
+			s.ScheduleAt(friday.Add(48*time.Hour), cmd)
+			return nil
+		}, 47 * time.Hour, 0},
+		{"in the past", func(s *Scheduler, cmd Command) error {
+			s.ScheduleAt(friday.Add(-time.Hour), cmd)
+			return nil
+		}, time.Hour, 1},
+		{"weekday mornings", func(s *Scheduler, cmd Command) error {
+			_, err := s.ScheduleCron("0 7 * * mon-fri", cmd)
+			return err
+		}, 7 * 24 * time.Hour, 5},
+		{"every hour", func(s *Scheduler, cmd Command) error {
+			_, err := s.ScheduleCron("0 * * * *", cmd)
+			return err
+		}, 10 * time.Hour, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := NewFakeClock(friday)
+			s := NewScheduler(clock)
+			cmd := NewTurnOnCommand(&Light{})
+			if err := tt.schedule(s, cmd); err != nil {
+				t.Fatal(err)
+			}
+			runs := 0
+			for step := time.Duration(0); step < tt.advance; step += time.Hour {
+				clock.Advance(time.Hour)
+				runs += s.RunDue()
+			}
+			if runs != tt.wantRuns {
+				t.Errorf("ran %d times, want %d", runs, tt.wantRuns)
+			}
+		})
+	}
+}
+
+func TestSchedulerPauseResume(t *testing.T) {
+	clock := NewFakeClock(friday)
+	s := NewScheduler(clock)
+	light := &Light{}
+	once := s.ScheduleAfter(time.Hour, NewTurnOnCommand(light))
+	hourly, err := s.ScheduleCron("30 * * * *", NewTurnOffCommand(&Light{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.Pause(once)
+	s.Pause(hourly)
+	clock.Advance(3 * time.Hour)
+	if n := s.RunDue(); n != 0 {
+		t.Fatalf("paused entries ran %d times", n)
+	}
+
+	// The one-shot is overdue and runs at once; the hourly entry skips what it missed.
+	s.Resume(once)
+	s.Resume(hourly)
+	if n := s.RunDue(); n != 1 || !light.IsOn() {
+		t.Fatalf("after resume ran %d entries, light on = %t; want 1, true", n, light.IsOn())
+	}
+	entries := s.Entries()
+	if len(entries) != 1 || !entries[0].Next.Equal(friday.Add(3*time.Hour+30*time.Minute)) {
+		t.Fatalf("entries after resume = %+v", entries)
+	}
+	if err := s.Remove(hourly); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Remove(hourly); err == nil {
+		t.Error("removing twice succeeded")
+	}
+}
+
+// startedCommand closes started when its command begins executing.
+type startedCommand struct {
+	Command
+	started chan struct{}
+}
+
+func (c *startedCommand) Execute() error {
+	close(c.started)
+	return c.Command.Execute()
+}
+
+func TestSchedulerOneShotListedWhileRunning(t *testing.T) {
+	clock := NewFakeClock(friday)
+	s := NewScheduler(clock)
+	release := make(chan struct{})
+	cmd := &startedCommand{Command: blockingCommand(release), started: make(chan struct{})}
+	id := s.ScheduleAfter(time.Minute, cmd)
+	clock.Advance(time.Hour)
+
+	done := make(chan int)
+	go func() { done <- s.RunDue() }()
+	<-cmd.started
+	entries := s.Entries()
+	if len(entries) != 1 || entries[0].ID != id || !entries[0].Next.IsZero() {
+		t.Fatalf("entries while running = %+v, want entry %d with a zero Next", entries, id)
+	}
+	if n := s.RunDue(); n != 0 {
+		t.Errorf("a running one-shot ran again %d times", n)
+	}
+	if _, ok := s.nextDue(); ok {
+		t.Error("a running one-shot is still due")
+	}
+
+	close(release)
+	if n := <-done; n != 1 {
+		t.Errorf("RunDue = %d, want 1", n)
+	}
+	if entries := s.Entries(); len(entries) != 0 {
+		t.Errorf("entries after the run = %+v, want none", entries)
+	}
+}
+
+func TestSchedulerRunReleasesTimers(t *testing.T) {
+	clock := NewFakeClock(friday)
+	s := NewScheduler(clock)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Run(ctx) }()
+
+	// Every change wakes Run, which replaces its timer.
+	for i := range 100 {
+		s.ScheduleAt(friday.Add(time.Duration(i+1)*time.Hour), NewTurnOnCommand(&Light{}))
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for clock.pending() > 1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := clock.pending(); n > 1 {
+		t.Errorf("%d timers pending, want at most 1", n)
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("Run returned %v, want context.Canceled", err)
+	}
+	if n := clock.pending(); n != 0 {
+		t.Errorf("%d timers pending after Run returned", n)
+	}
+}
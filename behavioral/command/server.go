@@ -0,0 +1,182 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxServerHistory bounds the executed-command log kept by a Server.
+const maxServerHistory = 1000
+
+// Device is a receiver that can describe its current state, e.g. for the HTTP API.
+type Device interface {
+	State() any
+}
+
+// ExecutedCommand is one entry of the Server's command history.
+type ExecutedCommand struct {
+	Seq     int       `json:"seq"`
+	Device  string    `json:"device"`
+	Command string    `json:"command"`
+	At      time.Time `json:"at"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// Server exposes registered devices and their named commands over a JSON HTTP API:
+//
+//	GET  /devices                          state of every device
+//	GET  /devices/{id}                     state of one device
+//	POST /devices/{id}/commands/{name}     execute a named command
+//	GET  /history                          executed commands, oldest first
+//
+// Server is an http.Handler, so it can be mounted anywhere or driven by httptest.
+// Requests are serialized, since receivers such as Light are not safe for concurrent use.
+type Server struct {
+	clock Clock
+	mux   *http.ServeMux
+
+	mu       sync.Mutex
+	devices  map[string]Device
+	commands map[string]map[string]Command
+	history  []ExecutedCommand
+	seq      int
+}
+
+func NewServer(clock Clock) *Server {
+	s := &Server{
+		clock:    clock,
+		mux:      http.NewServeMux(),
+		devices:  make(map[string]Device),
+		commands: make(map[string]map[string]Command),
+	}
+	s.mux.HandleFunc("GET /devices", s.handleListDevices)
+	s.mux.HandleFunc("GET /devices/{id}", s.handleGetDevice)
+	s.mux.HandleFunc("POST /devices/{id}/commands/{name}", s.handleExecute)
+	s.mux.HandleFunc("GET /history", s.handleHistory)
+	return s
+}
+
+func (s *Server) RegisterDevice(id string, device Device) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.devices[id] = device
+	if s.commands[id] == nil {
+		s.commands[id] = make(map[string]Command)
+	}
+}
+
+// RegisterCommand binds a named command to a registered device.
+func (s *Server) RegisterCommand(deviceID, name string, cmd Command) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.devices[deviceID]; !ok {
+		return fmt.Errorf("unknown device %q", deviceID)
+	}
+	s.commands[deviceID][name] = cmd
+	return nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+type deviceView struct {
+	ID       string   `json:"id"`
+	Commands []string `json:"commands"`
+	State    any      `json:"state"`
+}
+
+type executeResponse struct {
+	Seq   int `json:"seq"`
+	State any `json:"state"`
+}
+
+func (s *Server) handleListDevices(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	views := make([]deviceView, 0, len(s.devices))
+	for id := range s.devices {
+		views = append(views, s.viewLocked(id))
+	}
+	s.mu.Unlock()
+
+	sort.Slice(views, func(i, j int) bool { return views[i].ID < views[j].ID })
+	writeJSON(w, http.StatusOK, views)
+}
+
+func (s *Server) handleGetDevice(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.devices[id]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown device %q", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, s.viewLocked(id))
+}
+
+func (s *Server) handleExecute(w http.ResponseWriter, r *http.Request) {
+	id, name := r.PathValue("id"), r.PathValue("name")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	device, ok := s.devices[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown device %q", id))
+		return
+	}
+	cmd, ok := s.commands[id][name]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown command %q for device %q", name, id))
+		return
+	}
+
+	err := cmd.Execute()
+	s.seq++
+	entry := ExecutedCommand{Seq: s.seq, Device: id, Command: name, At: s.clock.Now()}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	s.history = append(s.history, entry)
+	if len(s.history) > maxServerHistory {
+		s.history = append(s.history[:0:0], s.history[len(s.history)-maxServerHistory:]...)
+	}
+
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("%s %s: %w", id, name, err))
+		return
+	}
+	writeJSON(w, http.StatusOK, executeResponse{Seq: entry.Seq, State: device.State()})
+}
+
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	history := append([]ExecutedCommand(nil), s.history...)
+	s.mu.Unlock()
+	if history == nil {
+		history = []ExecutedCommand{}
+	}
+	writeJSON(w, http.StatusOK, history)
+}
+
+func (s *Server) viewLocked(id string) deviceView {
+	names := make([]string, 0, len(s.commands[id]))
+	for name := range s.commands[id] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return deviceView{ID: id, Commands: names, State: s.devices[id].State()}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,112 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// failingCommand always fails, like a device that is offline.
+type failingCommand struct{}
+
+func (failingCommand) Execute() error { return errors.New("device offline") }
+func (failingCommand) Undo() error    { return nil }
+
+func newTestServer(t *testing.T) (*httptest.Server, *Light, *FakeClock) {
+	t.Helper()
+	clock := NewFakeClock(friday)
+	s := NewServer(clock)
+	porch := &Light{}
+	s.RegisterDevice("porch", porch)
+	s.RegisterCommand("porch", "on", NewTurnOnCommand(porch))
+	s.RegisterCommand("porch", "off", NewTurnOffCommand(porch))
+	s.RegisterCommand("porch", "broken", failingCommand{})
+	s.RegisterDevice("blind", &Blind{})
+	if err := s.RegisterCommand("garage", "open", failingCommand{}); err == nil {
+		t.Fatal("registering a command for an unknown device succeeded")
+	}
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+	return srv, porch, clock
+}
+
+func TestServerEndpoints(t *testing.T) {
+	srv, porch, _ := newTestServer(t)
+	tests := []struct {
+		method, path string
+		wantStatus   int
+		wantBody     string // Substring of the response body
+	}{
+		{"GET", "/devices", http.StatusOK, `[{"id":"blind","commands":[],"state":{"position":0}},{"id":"porch","commands":["broken","off","on"],"state":{"on":false}}]`},
+		{"GET", "/devices/porch", http.StatusOK, `"state":{"on":false}`},
+		{"GET", "/devices/garage", http.StatusNotFound, `unknown device \"garage\"`},
+		{"POST", "/devices/porch/commands/on", http.StatusOK, `{"seq":1,"state":{"on":true}}`},
+		{"POST", "/devices/porch/commands/dim", http.StatusNotFound, `unknown command \"dim\"`},
+		{"POST", "/devices/porch/commands/broken", http.StatusInternalServerError, "device offline"},
+		{"GET", "/devices/porch/commands/on", http.StatusMethodNotAllowed, ""},
+		{"POST", "/devices/porch/commands/off", http.StatusOK, `{"seq":3,"state":{"on":false}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, srv.URL+tt.path, nil)
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			var body bytes.Buffer
+			if _, err := body.ReadFrom(resp.Body); err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d (body %s)", resp.StatusCode, tt.wantStatus, body.String())
+			}
+			if !strings.Contains(body.String(), tt.wantBody) {
+				t.Errorf("body = %s, want it to contain %s", body.String(), tt.wantBody)
+			}
+		})
+	}
+	if porch.IsOn() {
+		t.Error("porch light is on after the final off command")
+	}
+}
+
+func TestServerHistory(t *testing.T) {
+	srv, _, clock := newTestServer(t)
+	for _, name := range []string{"on", "broken", "off"} {
+		resp, err := http.Post(srv.URL+"/devices/porch/commands/"+name, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		clock.Advance(time.Minute)
+	}
+
+	resp, err := http.Get(srv.URL + "/history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var history []ExecutedCommand
+	if err := json.NewDecoder(resp.Body).Decode(&history); err != nil {
+		t.Fatal(err)
+	}
+	want := []ExecutedCommand{
+		{Seq: 1, Device: "porch", Command: "on", At: friday},
+		{Seq: 2, Device: "porch", Command: "broken", At: friday.Add(time.Minute), Error: "device offline"},
+		{Seq: 3, Device: "porch", Command: "off", At: friday.Add(2 * time.Minute)},
+	}
+	if len(history) != len(want) {
+		t.Fatalf("history = %+v, want %+v", history, want)
+	}
+	for i := range want {
+		if history[i] != want[i] {
+			t.Errorf("history[%d] = %+v, want %+v", i, history[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// TransactionError reports a failed TransactionCommand: the step that failed and
+// every compensation that could not be applied while rolling back.
+type TransactionError struct {
+	Step         int   // 1-based index of the failing step
+	Err          error // Why the step failed
+	Compensation []error
+}
+
+func (e *TransactionError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "transaction step %d failed: %v", e.Step, e.Err)
+	if len(e.Compensation) == 0 {
+		b.WriteString(" (rolled back)")
+		return b.String()
+	}
+	fmt.Fprintf(&b, " (rollback incomplete: %v)", errors.Join(e.Compensation...))
+	return b.String()
+}
+
+// Unwrap exposes the step failure and every compensation failure to errors.Is/As.
+func (e *TransactionError) Unwrap() []error {
+	return append([]error{e.Err}, e.Compensation...)
+}
+
+// RolledBack reports whether every compensation succeeded, so no partial state remains.
+func (e *TransactionError) RolledBack() bool {
+	return len(e.Compensation) == 0
+}
+
+// TransactionCommand is a composite that applies all of its steps or none of them.
+// If step N fails, steps N-1 down to 1 are compensated with their Undo, in reverse order.
+// Unlike MacroCommand, a half-applied sequence is never left behind on purpose.
+type TransactionCommand struct {
+	steps []Command
+}
+
+func NewTransactionCommand(steps ...Command) *TransactionCommand {
+	return &TransactionCommand{steps: steps}
+}
+
+// Execute returns a *TransactionError when a step fails.
+func (t *TransactionCommand) Execute() error {
+	for i, step := range t.steps {
+		if err := step.Execute(); err != nil {
+			return &TransactionError{
+				Step:         i + 1,
+				Err:          err,
+				Compensation: compensate(t.steps[:i]),
+			}
+		}
+	}
+	return nil
+}
+
+// Undo reverts every step in reverse order and joins any failures.
+func (t *TransactionCommand) Undo() error {
+	return errors.Join(compensate(t.steps)...)
+}
+
+// Forget passes Forget on to the steps of the transaction.
+func (t *TransactionCommand) Forget() {
+	for _, step := range t.steps {
+		forget(step)
+	}
+}
+
+// compensate undoes the given steps from last to first. A failing compensation
+// does not stop the remaining ones.
+func compensate(steps []Command) []error {
+	var errs []error
+	for i := len(steps) - 1; i >= 0; i-- {
+		if err := steps[i].Undo(); err != nil {
+			errs = append(errs, fmt.Errorf("compensate step %d: %w", i+1, err))
+		}
+	}
+	return errs
+}
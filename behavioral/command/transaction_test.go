@@ -0,0 +1,111 @@
+package command
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTransactionCommand(t *testing.T) {
+	tests := []struct {
+		name           string
+		failExec       string
+		failUndo       []string
+		wantLog        []string
+		wantStep       int // 0 if the transaction commits
+		wantRolledBack bool
+		wantComp       []string
+	}{
+		{"commits", "", nil, []string{"a", "b", "c"}, 0, false, nil},
+		{"fails first step", "a", nil, []string{"a"}, 1, true, nil},
+		{"fails partway and rolls back", "c", nil, []string{"a", "b", "c", "undo b", "undo a"}, 3, true, nil},
+		{"failed compensations are aggregated", "c", []string{"a", "b"},
+			[]string{"a", "b", "c", "undo b", "undo a"}, 3, false,
+			[]string{"compensate step 2: b undo failed", "compensate step 1: a undo failed"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var log []string
+			var steps []Command
+			for _, name := range []string{"a", "b", "c"} {
+				steps = append(steps, &stepCommand{name: name, failExec: name == tt.failExec,
+					failUndo: slices.Contains(tt.failUndo, name), log: &log})
+			}
+			err := NewTransactionCommand(steps...).Execute()
+			if !slices.Equal(log, tt.wantLog) {
+				t.Errorf("ran %v, want %v", log, tt.wantLog)
+			}
+			if tt.wantStep == 0 {
+				if err != nil {
+					t.Errorf("Execute = %v, want nil", err)
+				}
+				return
+			}
+			var txErr *TransactionError
+			if !errors.As(err, &txErr) {
+				t.Fatalf("Execute = %v, want a *TransactionError", err)
+			}
+			if txErr.Step != tt.wantStep || txErr.RolledBack() != tt.wantRolledBack {
+				t.Errorf("step = %d, rolled back = %t; want %d, %t", txErr.Step, txErr.RolledBack(), tt.wantStep, tt.wantRolledBack)
+			}
+			var comp []string
+			for _, e := range txErr.Compensation {
+				comp = append(comp, e.Error())
+			}
+			if !slices.Equal(comp, tt.wantComp) {
+				t.Errorf("compensation errors = %q, want %q", comp, tt.wantComp)
+			}
+			if want := tt.failExec + " failed"; txErr.Err.Error() != want {
+				t.Errorf("step error = %v, want %q", txErr.Err, want)
+			}
+			// Every failure, the step's and the compensations', is reachable through the error.
+			for _, e := range append([]error{txErr.Err}, txErr.Compensation...) {
+				if !errors.Is(err, e) {
+					t.Errorf("errors.Is(err, %v) = false", e)
+				}
+			}
+			if wantSuffix := "(rolled back)"; tt.wantRolledBack != strings.HasSuffix(err.Error(), wantSuffix) {
+				t.Errorf("Error() = %q", err)
+			}
+		})
+	}
+}
+
+func TestTransactionRestoresReceivers(t *testing.T) {
+	light, dimmer, thermostat := &Light{}, &DimmableLight{}, &Thermostat{setpoint: 20, mode: ModeHeat}
+	tx := NewTransactionCommand(
+		NewTurnOnCommand(light),
+		NewSetSetpointCommand(thermostat, 23),
+		NewSetLevelCommand(dimmer, 150), // Out of range
+	)
+	var txErr *TransactionError
+	if err := tx.Execute(); !errors.As(err, &txErr) || txErr.Step != 3 || !txErr.RolledBack() {
+		t.Fatalf("Execute = %v, want step 3 rolled back", err)
+	}
+	if light.IsOn() || thermostat.Setpoint() != 20 || dimmer.Level() != 0 {
+		t.Errorf("after rollback light on = %t, setpoint = %v, level = %d", light.IsOn(), thermostat.Setpoint(), dimmer.Level())
+	}
+}
+
+func TestTransactionUndoAfterCommit(t *testing.T) {
+	light, thermostat := &Light{}, &Thermostat{setpoint: 20, mode: ModeHeat}
+	tx := NewTransactionCommand(NewTurnOnCommand(light), NewSetSetpointCommand(thermostat, 23))
+	if err := tx.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if !light.IsOn() || thermostat.Setpoint() != 23 {
+		t.Fatalf("after commit light on = %t, setpoint = %v", light.IsOn(), thermostat.Setpoint())
+	}
+	if err := tx.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if light.IsOn() || thermostat.Setpoint() != 20 {
+		t.Errorf("after Undo light on = %t, setpoint = %v; want off, 20", light.IsOn(), thermostat.Setpoint())
+	}
+	// Nothing is left to undo, and the failures of every step are joined.
+	err := tx.Undo()
+	if !errors.Is(err, ErrNothingToUndo) || len(errorLines(err)) != 2 {
+		t.Errorf("second Undo = %v, want ErrNothingToUndo from both steps", err)
+	}
+}
@@ -0,0 +1,194 @@
+package iterator
+
+import "iter"
+
+// --- Lazy combinators ---
+// Every combinator wraps an iter.Seq and pulls one element at a time from its source,
+// so pipelines such as Take(Filter(Map(...))) never build intermediate slices.
+// Only the terminal operations (Reduce, GroupBy, Collect) consume the whole sequence.
+
+// Map yields f(v) for every v in seq.
+func Map[T, U any](seq iter.Seq[T], f func(T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range seq {
+			if !yield(f(v)) {
+				return
+			}
+		}
+	}
+}
+
+// Filter yields the elements for which keep returns true.
+func Filter[T any](seq iter.Seq[T], keep func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if keep(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Take yields at most the first n elements and stops pulling from seq afterwards.
+func Take[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		taken := 0
+		for v := range seq {
+			if !yield(v) {
+				return
+			}
+			taken++
+			if taken == n {
+				return
+			}
+		}
+	}
+}
+
+// Skip drops the first n elements.
+func Skip[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		skipped := 0
+		for v := range seq {
+			if skipped < n {
+				skipped++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Zip pairs up elements of a and b, stopping at the end of the shorter sequence.
+func Zip[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		nextB, stop := iter.Pull(b)
+		defer stop()
+		for va := range a {
+			vb, ok := nextB()
+			if !ok || !yield(va, vb) {
+				return
+			}
+		}
+	}
+}
+
+// Chunk yields consecutive groups of n elements; the last group may be shorter.
+// Each chunk is a fresh slice that the caller may keep.
+func Chunk[T any](seq iter.Seq[T], n int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if n <= 0 {
+			return
+		}
+		chunk := make([]T, 0, n)
+		for v := range seq {
+			chunk = append(chunk, v)
+			if len(chunk) == n {
+				if !yield(chunk) {
+					return
+				}
+				chunk = make([]T, 0, n)
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// Window yields every run of n consecutive elements (a sliding window).
+// Sequences shorter than n yield nothing. The window is kept in a ring buffer of n
+// elements, so sliding never reallocates; each yielded window is still a fresh slice
+// the caller may keep, which costs one allocation and an O(n) copy per element.
+func Window[T any](seq iter.Seq[T], n int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if n <= 0 {
+			return
+		}
+		ring := make([]T, n)
+		seen := 0
+		for v := range seq {
+			ring[seen%n] = v
+			seen++
+			if seen < n {
+				continue
+			}
+			oldest := seen % n
+			out := make([]T, n)
+			copy(out, ring[oldest:])
+			copy(out[n-oldest:], ring[:oldest])
+			if !yield(out) {
+				return
+			}
+		}
+	}
+}
+
+// Dedup drops elements equal to the one just before them.
+func Dedup[T comparable](seq iter.Seq[T]) iter.Seq[T] {
+	return DedupFunc(seq, func(a, b T) bool { return a == b })
+}
+
+// DedupFunc drops elements that equal reports as the same as the one just before them.
+func DedupFunc[T any](seq iter.Seq[T], equal func(a, b T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var prev T
+		first := true
+		for v := range seq {
+			if !first && equal(prev, v) {
+				continue
+			}
+			first = false
+			prev = v
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Flatten yields the elements of each inner sequence in turn.
+func Flatten[T any](seqs iter.Seq[iter.Seq[T]]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for inner := range seqs {
+			for v := range inner {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Reduce folds seq into a single value, starting from initial.
+func Reduce[T, U any](seq iter.Seq[T], initial U, f func(U, T) U) U {
+	acc := initial
+	for v := range seq {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// GroupBy buckets the elements by key, keeping their order within each bucket.
+func GroupBy[T any, K comparable](seq iter.Seq[T], key func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for v := range seq {
+		k := key(v)
+		groups[k] = append(groups[k], v)
+	}
+	return groups
+}
+
+// Collect gathers the elements into a slice.
+func Collect[T any](seq iter.Seq[T]) []T {
+	var out []T
+	for v := range seq {
+		out = append(out, v)
+	}
+	return out
+}
@@ -0,0 +1,160 @@
+package iterator
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"testing"
+)
+
+// counted yields 1..n and counts how many elements were pulled from it.
+func counted(n int, pulled *int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 1; i <= n; i++ {
+			*pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// upTo collects at most limit elements of seq; a negative limit collects all.
+func upTo[T any](seq iter.Seq[T], limit int) []T {
+	var out []T
+	if limit == 0 {
+		return out
+	}
+	for v := range seq {
+		out = append(out, v)
+		if len(out) == limit {
+			break
+		}
+	}
+	return out
+}
+
+func TestCombinators(t *testing.T) {
+	double := func(seq iter.Seq[int]) iter.Seq[int] { return Map(seq, func(v int) int { return 2 * v }) }
+	even := func(seq iter.Seq[int]) iter.Seq[int] { return Filter(seq, func(v int) bool { return v%2 == 0 }) }
+	take := func(n int) func(iter.Seq[int]) iter.Seq[int] {
+		return func(seq iter.Seq[int]) iter.Seq[int] { return Take(seq, n) }
+	}
+	tests := []struct {
+		name       string
+		source     int // Elements in the source, 1..source
+		apply      func(iter.Seq[int]) iter.Seq[int]
+		limit      int // Break after this many results; -1 reads all
+		want       []int
+		wantPulled int
+	}{
+		{"map", 4, double, -1, []int{2, 4, 6, 8}, 4},
+		{"map, empty", 0, double, -1, nil, 0},
+		{"map, break early", 4, double, 2, []int{2, 4}, 2},
+		{"filter", 6, even, -1, []int{2, 4, 6}, 6},
+		{"filter, none kept", 1, even, -1, nil, 1},
+		{"filter, break early", 6, even, 1, []int{2}, 2},
+		{"take", 10, take(3), -1, []int{1, 2, 3}, 3},
+		{"take more than there is", 2, take(5), -1, []int{1, 2}, 2},
+		{"take zero", 10, take(0), -1, nil, 0},
+		{"take negative", 10, take(-1), -1, nil, 0},
+		{"take, break early", 10, take(5), 2, []int{1, 2}, 2},
+		{"take, empty", 0, take(3), -1, nil, 0},
+		{"pipeline pulls lazily", 100, func(seq iter.Seq[int]) iter.Seq[int] { return Take(even(double(seq)), 3) }, -1, []int{2, 4, 6}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pulled := 0
+			got := upTo(tt.apply(counted(tt.source, &pulled)), tt.limit)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if pulled != tt.wantPulled {
+				t.Errorf("pulled %d elements from the source, want %d", pulled, tt.wantPulled)
+			}
+		})
+	}
+}
+
+func TestZip(t *testing.T) {
+	letters := func(s string) iter.Seq[string] {
+		return func(yield func(string) bool) {
+			for _, r := range s {
+				if !yield(string(r)) {
+					return
+				}
+			}
+		}
+	}
+	tests := []struct {
+		name        string
+		a           int
+		b           string
+		limit       int
+		want        []string
+		wantAPulled int
+	}{
+		{"same length", 3, "abc", -1, []string{"1a", "2b", "3c"}, 3},
+		{"a shorter", 2, "abcd", -1, []string{"1a", "2b"}, 2},
+		{"b shorter", 5, "ab", -1, []string{"1a", "2b"}, 3},
+		{"a empty", 0, "abc", -1, nil, 0},
+		{"b empty", 3, "", -1, nil, 1},
+		{"break early", 5, "abcde", 2, []string{"1a", "2b"}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pulled := 0
+			var got []string
+			for a, b := range Zip(counted(tt.a, &pulled), letters(tt.b)) {
+				got = append(got, fmt.Sprint(a, b))
+				if len(got) == tt.limit {
+					break
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if pulled != tt.wantAPulled {
+				t.Errorf("pulled %d elements from a, want %d", pulled, tt.wantAPulled)
+			}
+		})
+	}
+}
+
+func TestWindow(t *testing.T) {
+	tests := []struct {
+		name   string
+		source int
+		n      int
+		limit  int
+		want   [][]int
+	}{
+		{"slides", 5, 3, -1, [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}},
+		{"exact length", 3, 3, -1, [][]int{{1, 2, 3}}},
+		{"shorter than the window", 2, 3, -1, nil},
+		{"window of one", 3, 1, -1, [][]int{{1}, {2}, {3}}},
+		{"zero size", 5, 0, -1, nil},
+		{"empty", 0, 2, -1, nil},
+		{"wraps the ring several times", 8, 3, -1, [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}, {4, 5, 6}, {5, 6, 7}, {6, 7, 8}}},
+		{"break early", 10, 2, 2, [][]int{{1, 2}, {2, 3}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pulled := 0
+			got := upTo(Window(counted(tt.source, &pulled), tt.n), tt.limit)
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Windows are fresh slices: keeping one must not see later slides.
+	pulled := 0
+	var kept [][]int
+	for w := range Window(counted(6, &pulled), 2) {
+		kept = append(kept, w)
+	}
+	if want := [][]int{{1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 6}}; !slices.EqualFunc(kept, want, slices.Equal) {
+		t.Errorf("kept windows = %v, want %v", kept, want)
+	}
+}
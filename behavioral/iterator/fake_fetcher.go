@@ -0,0 +1,68 @@
+package iterator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// FakePageFetcher is an in-memory PageFetcher for demos and tests. Its cursors are
+// book offsets, each fetch waits Latency, and Fail can inject errors.
+type FakePageFetcher struct {
+	Books   []*Book
+	Latency time.Duration
+	// Fail, if set, is called before every fetch with the 1-based call number and cursor;
+	// a non-nil result is returned instead of the page.
+	Fail func(call int, cursor string) error
+
+	mu    sync.Mutex
+	calls int
+}
+
+func NewFakePageFetcher(books []*Book, latency time.Duration) *FakePageFetcher {
+	return &FakePageFetcher{Books: books, Latency: latency}
+}
+
+func (f *FakePageFetcher) FetchPage(ctx context.Context, cursor string, limit int) (Page, error) {
+	f.mu.Lock()
+	f.calls++
+	call := f.calls
+	f.mu.Unlock()
+
+	if f.Latency > 0 {
+		select {
+		case <-time.After(f.Latency):
+		case <-ctx.Done():
+			return Page{}, ctx.Err()
+		}
+	}
+	if f.Fail != nil {
+		if err := f.Fail(call, cursor); err != nil {
+			return Page{}, err
+		}
+	}
+
+	start := 0
+	if cursor != "" {
+		n, err := strconv.Atoi(cursor)
+		if err != nil || n < 0 || n > len(f.Books) {
+			return Page{}, fmt.Errorf("unknown cursor %q", cursor)
+		}
+		start = n
+	}
+	end := min(start+limit, len(f.Books))
+	page := Page{Books: f.Books[start:end]}
+	if end < len(f.Books) {
+		page.NextCursor = strconv.Itoa(end)
+	}
+	return page, nil
+}
+
+// Calls reports how many pages have been requested so far.
+func (f *FakePageFetcher) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
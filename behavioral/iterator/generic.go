@@ -0,0 +1,91 @@
+package iterator
+
+import "iter"
+
+// --- Generic Iterator and Aggregate ---
+// The same pattern as BookIterator/BookCollection, for any element type.
+
+// Iterator is the generic form of BookIterator.
+type Iterator[T any] interface {
+	HasNext() bool
+	Next() T // Returns the zero value once the iterator is exhausted
+	Reset()
+}
+
+// Collection is a generic aggregate backed by a slice.
+type Collection[T any] struct {
+	items []T
+}
+
+func NewCollection[T any](items ...T) *Collection[T] {
+	return &Collection[T]{items: append(make([]T, 0, len(items)), items...)}
+}
+
+func (c *Collection[T]) Add(item T) {
+	c.items = append(c.items, item)
+}
+
+func (c *Collection[T]) Len() int {
+	return len(c.items)
+}
+
+func (c *Collection[T]) CreateIterator() Iterator[T] {
+	return &CollectionIterator[T]{collection: c}
+}
+
+// All yields the items in insertion order.
+func (c *Collection[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range c.items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Backward yields the items from last to first.
+func (c *Collection[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := len(c.items) - 1; i >= 0; i-- {
+			if !yield(c.items[i]) {
+				return
+			}
+		}
+	}
+}
+
+// CollectionIterator implements Iterator for Collection.
+type CollectionIterator[T any] struct {
+	collection *Collection[T]
+	index      int
+}
+
+func (ci *CollectionIterator[T]) HasNext() bool {
+	return ci.index < len(ci.collection.items)
+}
+
+func (ci *CollectionIterator[T]) Next() T {
+	if !ci.HasNext() {
+		var zero T
+		return zero
+	}
+	item := ci.collection.items[ci.index]
+	ci.index++
+	return item
+}
+
+func (ci *CollectionIterator[T]) Reset() {
+	ci.index = 0
+}
+
+// Values adapts any generic Iterator to an iter.Seq, continuing from its current position.
+func Values[T any](it Iterator[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for it.HasNext() {
+			if !yield(it.Next()) {
+				return
+			}
+		}
+	}
+}
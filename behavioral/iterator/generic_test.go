@@ -0,0 +1,55 @@
+package iterator
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCollection(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []int
+	}{
+		{"empty", nil},
+		{"one", []int{7}},
+		{"several", []int{3, 1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCollection(tt.items...)
+			reversed := slices.Clone(tt.items)
+			slices.Reverse(reversed)
+			if got := Collect(c.All()); !slices.Equal(got, tt.items) {
+				t.Errorf("All = %v, want %v", got, tt.items)
+			}
+			if got := Collect(c.Backward()); !slices.Equal(got, reversed) {
+				t.Errorf("Backward = %v, want %v", got, reversed)
+			}
+			it := c.CreateIterator()
+			if got := Collect(Values(it)); !slices.Equal(got, tt.items) {
+				t.Errorf("Values = %v, want %v", got, tt.items)
+			}
+			if it.HasNext() || it.Next() != 0 {
+				t.Error("exhausted iterator still has items")
+			}
+			it.Reset()
+			if got := Collect(Values(it)); !slices.Equal(got, tt.items) {
+				t.Errorf("Values after Reset = %v, want %v", got, tt.items)
+			}
+		})
+	}
+
+	// NewCollection copies its arguments, and Values continues where the iterator is.
+	items := []int{1, 2, 3}
+	c := NewCollection(items...)
+	items[0] = 99
+	c.Add(4)
+	it := c.CreateIterator()
+	it.Next()
+	if got := upTo(Values(it), 2); !slices.Equal(got, []int{2, 3}) {
+		t.Errorf("Values from the second item = %v, want [2 3]", got)
+	}
+	if got := it.Next(); got != 4 || c.Len() != 4 {
+		t.Errorf("Next after the loop = %d with Len %d, want 4 and 4", got, c.Len())
+	}
+}
@@ -0,0 +1,182 @@
+package iterator
+
+import (
+	"iter"
+	"math/rand/v2"
+	"strings"
+)
+
+// --- Secondary indexes ---
+// BookCollection keeps one skip list per indexed field next to its insertion-ordered
+// slice. Index scans lock the collection only to find the next entry, and resume by
+// key, so the collection may be modified from inside the loop: books added after the
+// current position still show up, removed ones do not.
+
+// BookIndex selects the field an ordered scan walks.
+type BookIndex int
+
+const (
+	ByAuthor BookIndex = iota
+	ByTitle
+)
+
+func (i BookIndex) String() string {
+	switch i {
+	case ByAuthor:
+		return "author"
+	case ByTitle:
+		return "title"
+	default:
+		return "unknown"
+	}
+}
+
+// Ordered yields every book sorted by the field, ties in insertion order.
+// Keys compare byte-wise, so the order is case-sensitive.
+func (bc *BookCollection) Ordered(index BookIndex) iter.Seq[*Book] {
+	return bc.scan(index, "", func(string) bool { return true })
+}
+
+// WithPrefix yields, in order, the books whose field starts with prefix.
+func (bc *BookCollection) WithPrefix(index BookIndex, prefix string) iter.Seq[*Book] {
+	return bc.scan(index, prefix, func(key string) bool { return strings.HasPrefix(key, prefix) })
+}
+
+// InRange yields, in order, the books whose field is in [from, to).
+// An empty to leaves the range open-ended.
+func (bc *BookCollection) InRange(index BookIndex, from, to string) iter.Seq[*Book] {
+	return bc.scan(index, from, func(key string) bool { return to == "" || key < to })
+}
+
+// scan walks the index from the first key >= from while keep accepts the keys.
+func (bc *BookCollection) scan(index BookIndex, from string, keep func(key string) bool) iter.Seq[*Book] {
+	return func(yield func(*Book) bool) {
+		cursor := indexKey{key: from}
+		for {
+			bc.mu.RLock()
+			node := bc.indexFor(index).seek(cursor)
+			bc.mu.RUnlock()
+			// A node's key and book never change, so they are safe to read unlocked.
+			if node == nil || !keep(node.key.key) || !yield(node.book) {
+				return
+			}
+			cursor = indexKey{key: node.key.key, id: node.key.id + 1}
+		}
+	}
+}
+
+func (bc *BookCollection) indexFor(index BookIndex) *skipList {
+	if index == ByTitle {
+		return bc.byTitle
+	}
+	return bc.byAuthor
+}
+
+// indexedBook records the keys a book was indexed under, so it can be removed
+// even if its fields were changed afterwards.
+type indexedBook struct {
+	id     uint64
+	author string
+	title  string
+}
+
+// --- Skip list ---
+
+const skipListMaxLevel = 16
+
+// indexKey orders entries by field value, then by insertion id to keep duplicates apart.
+type indexKey struct {
+	key string
+	id  uint64
+}
+
+func (a indexKey) less(b indexKey) bool {
+	if a.key != b.key {
+		return a.key < b.key
+	}
+	return a.id < b.id
+}
+
+type skipNode struct {
+	key  indexKey
+	book *Book
+	next []*skipNode
+}
+
+// skipList is an ordered map from indexKey to book with expected O(log n) operations.
+// It is not safe for concurrent use; BookCollection guards it with its mutex.
+type skipList struct {
+	head  *skipNode
+	level int
+	len   int
+}
+
+func newSkipList() *skipList {
+	return &skipList{head: &skipNode{next: make([]*skipNode, skipListMaxLevel)}, level: 1}
+}
+
+// predecessors fills update with the last node before key on every level.
+func (s *skipList) predecessors(key indexKey, update *[skipListMaxLevel]*skipNode) {
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].key.less(key) {
+			x = x.next[i]
+		}
+		update[i] = x
+	}
+}
+
+func (s *skipList) insert(key indexKey, book *Book) {
+	var update [skipListMaxLevel]*skipNode
+	s.predecessors(key, &update)
+
+	level := randomLevel()
+	for i := s.level; i < level; i++ {
+		update[i] = s.head
+	}
+	s.level = max(s.level, level)
+
+	node := &skipNode{key: key, book: book, next: make([]*skipNode, level)}
+	for i := range level {
+		node.next[i] = update[i].next[i]
+		update[i].next[i] = node
+	}
+	s.len++
+}
+
+func (s *skipList) remove(key indexKey) bool {
+	var update [skipListMaxLevel]*skipNode
+	s.predecessors(key, &update)
+
+	node := update[0].next[0]
+	if node == nil || node.key != key {
+		return false
+	}
+	for i := range node.next {
+		update[i].next[i] = node.next[i]
+	}
+	for s.level > 1 && s.head.next[s.level-1] == nil {
+		s.level--
+	}
+	s.len--
+	return true
+}
+
+// seek returns the first node whose key is >= key, or nil. A nil list is empty.
+func (s *skipList) seek(key indexKey) *skipNode {
+	if s == nil {
+		return nil
+	}
+	var update [skipListMaxLevel]*skipNode
+	s.predecessors(key, &update)
+	return update[0].next[0]
+}
+
+// randomLevel picks a node height with a 1/4 chance of each extra level.
+func randomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && rand.IntN(4) == 0 {
+		level++
+	}
+	return level
+}
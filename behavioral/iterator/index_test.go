@@ -0,0 +1,207 @@
+package iterator
+
+import (
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// keys walks the bottom level of s and returns its keys in order.
+func (s *skipList) keys() []indexKey {
+	var out []indexKey
+	for n := s.head.next[0]; n != nil; n = n.next[0] {
+		out = append(out, n.key)
+	}
+	return out
+}
+
+func TestSkipList(t *testing.T) {
+	s := newSkipList()
+	var want []indexKey
+	for _, i := range rand.Perm(200) {
+		key := indexKey{key: fmt.Sprintf("k%02d", i%50), id: uint64(i)} // Every key four times
+		s.insert(key, nil)
+		want = append(want, key)
+	}
+	slices.SortFunc(want, func(a, b indexKey) int {
+		if a.less(b) {
+			return -1
+		}
+		return 1
+	})
+	if got := s.keys(); !slices.Equal(got, want) {
+		t.Fatalf("keys out of order:\n%v\nwant\n%v", got, want)
+	}
+
+	tests := []struct {
+		name string
+		seek indexKey
+		want indexKey
+		nil  bool
+	}{
+		{"before everything", indexKey{}, want[0], false},
+		{"exact", want[17], want[17], false},
+		{"between duplicates", indexKey{key: want[5].key, id: want[5].id + 1}, want[6], false},
+		{"missing key", indexKey{key: "k10x"}, indexKey{key: "k11", id: want[44].id}, false},
+		{"past the end", indexKey{key: "z"}, indexKey{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := s.seek(tt.seek)
+			if tt.nil {
+				if node != nil {
+					t.Errorf("seek = %v, want nil", node.key)
+				}
+				return
+			}
+			if node == nil || node.key != tt.want {
+				t.Errorf("seek = %v, want %v", node, tt.want)
+			}
+		})
+	}
+
+	// Remove the head, the last element and one in the middle, then everything.
+	for _, key := range []indexKey{want[0], want[len(want)-1], want[100]} {
+		if !s.remove(key) {
+			t.Fatalf("remove %v failed", key)
+		}
+		if s.remove(key) {
+			t.Errorf("removed %v twice", key)
+		}
+		want = slices.DeleteFunc(want, func(k indexKey) bool { return k == key })
+	}
+	if got := s.keys(); !slices.Equal(got, want) || s.len != len(want) {
+		t.Fatalf("after removals %d keys (len %d), want %d", len(got), s.len, len(want))
+	}
+	if s.remove(indexKey{key: "k00", id: 999}) {
+		t.Error("removed a key that was never inserted")
+	}
+	for _, key := range want {
+		s.remove(key)
+	}
+	if s.len != 0 || s.level != 1 || s.seek(indexKey{}) != nil {
+		t.Errorf("emptied list has len %d, level %d", s.len, s.level)
+	}
+	var empty *skipList
+	if empty.seek(indexKey{}) != nil {
+		t.Error("nil list is not empty")
+	}
+}
+
+func TestIndexScans(t *testing.T) {
+	// Title and author keys, with duplicates added out of order.
+	books := []*Book{
+		{Title: "Emma", Author: "Austen"},
+		{Title: "Dune", Author: "Herbert"},
+		{Title: "Persuasion", Author: "Austen"},
+		{Title: "Dune Messiah", Author: "Herbert"},
+		{Title: "Dune", Author: "Anderson"},
+		{Title: "Duo", Author: "Colette"},
+		{Title: "emma", Author: "nobody"},
+	}
+	bc := collection(books)
+	tests := []struct {
+		name string
+		seq  iter.Seq[*Book]
+		want []*Book
+	}{
+		{"by title, ties in insertion order", bc.Ordered(ByTitle),
+			[]*Book{books[1], books[4], books[3], books[5], books[0], books[2], books[6]}},
+		{"by author", bc.Ordered(ByAuthor),
+			[]*Book{books[4], books[0], books[2], books[5], books[1], books[3], books[6]}},
+		{"prefix", bc.WithPrefix(ByTitle, "Dune"),
+			[]*Book{books[1], books[4], books[3]}},
+		{"prefix is case-sensitive", bc.WithPrefix(ByTitle, "emma"), []*Book{books[6]}},
+		{"prefix stops at the first key past it", bc.WithPrefix(ByTitle, "Du"),
+			[]*Book{books[1], books[4], books[3], books[5]}},
+		{"prefix matching nothing", bc.WithPrefix(ByTitle, "Dv"), nil},
+		{"prefix past every key", bc.WithPrefix(ByTitle, "zz"), nil},
+		{"empty prefix", bc.WithPrefix(ByAuthor, ""),
+			[]*Book{books[4], books[0], books[2], books[5], books[1], books[3], books[6]}},
+		{"range", bc.InRange(ByAuthor, "Austen", "Herbert"),
+			[]*Book{books[0], books[2], books[5]}},
+		{"range is half-open", bc.InRange(ByTitle, "Dune Messiah", "Emma"),
+			[]*Book{books[3], books[5]}},
+		{"open-ended range", bc.InRange(ByAuthor, "Herbert", ""),
+			[]*Book{books[1], books[3], books[6]}},
+		{"empty range", bc.InRange(ByAuthor, "Austen", "Austen"), nil},
+		{"inverted range", bc.InRange(ByAuthor, "Herbert", "Austen"), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []*Book
+			for b := range tt.seq {
+				got = append(got, b)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", titles(got), titles(tt.want))
+			}
+		})
+	}
+}
+
+func TestIndexAfterRemoveBook(t *testing.T) {
+	books := []*Book{
+		{Title: "B", Author: "x"},
+		{Title: "A", Author: "x"},
+		{Title: "C", Author: "x"},
+		{Title: "A", Author: "y"},
+	}
+	tests := []struct {
+		name   string
+		remove []*Book
+		want   []*Book // By title
+	}{
+		{"head", []*Book{books[1]}, []*Book{books[3], books[0], books[2]}},
+		{"last", []*Book{books[2]}, []*Book{books[1], books[3], books[0]}},
+		{"one of two duplicates", []*Book{books[3]}, []*Book{books[1], books[0], books[2]}},
+		{"everything", books, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bc := collection(books)
+			for _, b := range tt.remove {
+				if !bc.RemoveBook(b) {
+					t.Fatalf("RemoveBook(%v) = false", b)
+				}
+			}
+			if got := Collect(bc.Ordered(ByTitle)); !slices.Equal(got, tt.want) {
+				t.Errorf("by title %v, want %v", titles(got), titles(tt.want))
+			}
+			if n := bc.byTitle.len + bc.byAuthor.len; n != 2*len(tt.want) {
+				t.Errorf("indexes hold %d entries, want %d", n, 2*len(tt.want))
+			}
+		})
+	}
+
+	// A book renamed after it was added is still removed under its indexed keys.
+	bc := collection(books)
+	books[0].Title = "Z"
+	defer func() { books[0].Title = "B" }()
+	bc.RemoveBook(books[0])
+	if got := Collect(bc.Ordered(ByTitle)); len(got) != 3 || slices.Contains(got, books[0]) {
+		t.Errorf("after removing a renamed book got %v", titles(got))
+	}
+}
+
+func TestZeroValueCollection(t *testing.T) {
+	var bc BookCollection
+	if got := Collect(bc.Ordered(ByAuthor)); len(got) != 0 {
+		t.Errorf("empty collection yielded %v", titles(got))
+	}
+	if bc.RemoveBook(&Book{}) {
+		t.Error("removed a book from an empty collection")
+	}
+	books := catalog(3)
+	for _, b := range slices.Backward(books) {
+		bc.AddBook(b)
+	}
+	if got := Collect(bc.Ordered(ByTitle)); !slices.Equal(got, books) {
+		t.Errorf("by title %v, want %v", titles(got), titles(books))
+	}
+	if got := Collect(bc.All()); len(got) != 3 || got[0] != books[2] {
+		t.Errorf("All = %v", titles(got))
+	}
+}
@@ -0,0 +1,204 @@
+package iterator
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// ErrConcurrentModification is reported by a fail-fast iterator whose collection
+// was modified after the iterator was created or last reset.
+var ErrConcurrentModification = errors.New("collection modified during iteration")
+
+// --- Product (just for context) ---
+type Book struct {
+	Title  string
+	Author string
+}
+
+func (b Book) String() string {
+	return fmt.Sprintf("'%s' by %s", b.Title, b.Author)
+}
+
+// --- 1. Iterator (Interface) ---
+type BookIterator interface {
+	HasNext() bool
+	Next() *Book // Returns the next book
+	Reset()      // Resets the iterator to the beginning
+	Err() error  // Reports why iteration stopped early, nil if it simply ended
+}
+
+// --- 4. Concrete Aggregate ---
+// A custom collection of books. It is safe for concurrent readers and writers.
+// The zero value is an empty collection ready to use.
+type BookCollection struct {
+	mu       sync.RWMutex
+	books    []*Book       // Our internal representation (a slice)
+	entries  []indexedBook // Index keys of books[i], never shared with snapshots
+	nextID   uint64
+	byAuthor *skipList
+	byTitle  *skipList
+	modCount uint64 // Incremented on every change, checked by fail-fast iterators
+	shared   bool   // books is referenced by a snapshot and must be copied before writing
+}
+
+func NewBookCollection() *BookCollection {
+	return &BookCollection{
+		books:    make([]*Book, 0),
+		byAuthor: newSkipList(),
+		byTitle:  newSkipList(),
+	}
+}
+
+func (bc *BookCollection) AddBook(book *Book) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	bc.prepareWrite()
+	bc.books = append(bc.books, book)
+
+	entry := indexedBook{id: bc.nextID, author: book.Author, title: book.Title}
+	bc.nextID++
+	bc.entries = append(bc.entries, entry)
+	bc.byAuthor.insert(indexKey{key: entry.author, id: entry.id}, book)
+	bc.byTitle.insert(indexKey{key: entry.title, id: entry.id}, book)
+	bc.modCount++
+}
+
+// RemoveBook removes the first occurrence of book (compared by pointer) and
+// reports whether it was found.
+func (bc *BookCollection) RemoveBook(book *Book) bool {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	i := slices.Index(bc.books, book)
+	if i < 0 {
+		return false
+	}
+	bc.prepareWrite()
+	bc.books = slices.Delete(bc.books, i, i+1)
+
+	entry := bc.entries[i]
+	bc.entries = slices.Delete(bc.entries, i, i+1)
+	bc.byAuthor.remove(indexKey{key: entry.author, id: entry.id})
+	bc.byTitle.remove(indexKey{key: entry.title, id: entry.id})
+	bc.modCount++
+	return true
+}
+
+func (bc *BookCollection) Len() int {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	return len(bc.books)
+}
+
+// snapshot returns the current books without copying them. The slice is marked as
+// shared, so the next write copies it first (copy-on-write) and the snapshot stays stable.
+func (bc *BookCollection) snapshot() []*Book {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	bc.shared = true
+	return bc.books[:len(bc.books):len(bc.books)]
+}
+
+// prepareWrite detaches books from any snapshot and creates the indexes of a zero-value
+// collection. Callers must hold the write lock.
+func (bc *BookCollection) prepareWrite() {
+	if bc.byAuthor == nil {
+		bc.byAuthor, bc.byTitle = newSkipList(), newSkipList()
+	}
+	if bc.shared {
+		bc.books = append(make([]*Book, 0, len(bc.books)+1), bc.books...)
+		bc.shared = false
+	}
+}
+
+// --- 3. Aggregate (Interface) -- Implemented by BookCollection
+// CreateIterator is the "factory method" for creating an iterator.
+// The iterator is fail-fast: once the collection changes, it stops and reports
+// ErrConcurrentModification through Err.
+func (bc *BookCollection) CreateIterator() BookIterator {
+	it := &BookCollectionIterator{collection: bc}
+	it.Reset()
+	return it
+}
+
+// CreateSnapshotIterator returns an iterator over the books as they are right now.
+// Later changes to the collection are not visible to it and never invalidate it.
+func (bc *BookCollection) CreateSnapshotIterator() BookIterator {
+	return &SnapshotIterator{books: bc.snapshot()}
+}
+
+// --- 2. Concrete Iterator ---
+// Implements the BookIterator interface for BookCollection.
+type BookCollectionIterator struct {
+	collection       *BookCollection // Reference to the aggregate
+	index            int             // Current position
+	expectedModCount uint64          // Collection version this iterator was created for
+	err              error
+}
+
+func (bci *BookCollectionIterator) HasNext() bool {
+	bci.collection.mu.RLock()
+	defer bci.collection.mu.RUnlock()
+	return bci.checkLocked() && bci.index < len(bci.collection.books)
+}
+
+func (bci *BookCollectionIterator) Next() *Book {
+	bci.collection.mu.RLock()
+	defer bci.collection.mu.RUnlock()
+	if bci.checkLocked() && bci.index < len(bci.collection.books) {
+		book := bci.collection.books[bci.index]
+		bci.index++ // Move to the next element
+		return book
+	}
+	return nil // Check Err to tell the end from a concurrent modification
+}
+
+// Reset rewinds the iterator and accepts the collection's current contents.
+func (bci *BookCollectionIterator) Reset() {
+	bci.collection.mu.RLock()
+	defer bci.collection.mu.RUnlock()
+	bci.index = 0
+	bci.expectedModCount = bci.collection.modCount
+	bci.err = nil
+}
+
+func (bci *BookCollectionIterator) Err() error {
+	return bci.err
+}
+
+// checkLocked records ErrConcurrentModification if the collection changed.
+// Callers must hold the collection's read lock.
+func (bci *BookCollectionIterator) checkLocked() bool {
+	if bci.err == nil && bci.collection.modCount != bci.expectedModCount {
+		bci.err = ErrConcurrentModification
+	}
+	return bci.err == nil
+}
+
+// SnapshotIterator walks a copy-on-write snapshot of a BookCollection.
+type SnapshotIterator struct {
+	books []*Book
+	index int
+}
+
+func (si *SnapshotIterator) HasNext() bool {
+	return si.index < len(si.books)
+}
+
+func (si *SnapshotIterator) Next() *Book {
+	if !si.HasNext() {
+		return nil
+	}
+	book := si.books[si.index]
+	si.index++
+	return book
+}
+
+func (si *SnapshotIterator) Reset() {
+	si.index = 0
+}
+
+func (si *SnapshotIterator) Err() error {
+	return nil
+}
@@ -0,0 +1,123 @@
+package iterator
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestFailFastIterator(t *testing.T) {
+	books := catalog(4)
+	tests := []struct {
+		name    string
+		modify  func(bc *BookCollection)
+		wantErr error
+	}{
+		{"no change", func(*BookCollection) {}, nil},
+		{"add", func(bc *BookCollection) { bc.AddBook(&Book{Title: "Late"}) }, ErrConcurrentModification},
+		{"remove", func(bc *BookCollection) { bc.RemoveBook(books[3]) }, ErrConcurrentModification},
+		{"remove a missing book", func(bc *BookCollection) { bc.RemoveBook(&Book{}) }, nil},
+		{"snapshot", func(bc *BookCollection) { bc.CreateSnapshotIterator() }, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bc := collection(books)
+			it := bc.CreateIterator()
+			it.Next()
+			tt.modify(bc)
+			rest, err := drain(it)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil {
+				if len(rest) != 3 {
+					t.Errorf("read %d more books, want 3", len(rest))
+				}
+				return
+			}
+			if len(rest) != 0 || it.HasNext() || it.Next() != nil {
+				t.Errorf("iterator went on after the modification: %v", titles(rest))
+			}
+
+			// Reset accepts the new contents.
+			it.Reset()
+			got, err := drain(it)
+			if err != nil || len(got) != bc.Len() {
+				t.Errorf("after Reset read %d books, err %v; want %d, nil", len(got), err, bc.Len())
+			}
+		})
+	}
+}
+
+func TestSnapshotIteratorKeepsItsView(t *testing.T) {
+	books := catalog(5)
+	tests := []struct {
+		name   string
+		modify func(bc *BookCollection)
+		want   []*Book // Contents of the collection afterwards
+	}{
+		{"add", func(bc *BookCollection) { bc.AddBook(&Book{Title: "Late"}) }, nil},
+		{"remove the head", func(bc *BookCollection) { bc.RemoveBook(books[0]) }, books[1:]},
+		{"remove from the middle", func(bc *BookCollection) { bc.RemoveBook(books[2]) },
+			[]*Book{books[0], books[1], books[3], books[4]}},
+		{"remove the last", func(bc *BookCollection) { bc.RemoveBook(books[4]) }, books[:4]},
+		{"remove all, then add", func(bc *BookCollection) {
+			for _, b := range books {
+				bc.RemoveBook(b)
+			}
+			bc.AddBook(books[0])
+		}, books[:1]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bc := collection(books)
+			snap := bc.CreateSnapshotIterator()
+			snap.Next()
+			tt.modify(bc)
+
+			rest, err := drain(snap)
+			if err != nil || !slices.Equal(rest, books[1:]) {
+				t.Errorf("snapshot read %v, err %v; want %v", titles(rest), err, titles(books[1:]))
+			}
+			snap.Reset()
+			if all, _ := drain(snap); !slices.Equal(all, books) {
+				t.Errorf("snapshot after Reset = %v, want %v", titles(all), titles(books))
+			}
+			if tt.want != nil {
+				if now, _ := drain(bc.CreateSnapshotIterator()); !slices.Equal(now, tt.want) {
+					t.Errorf("new snapshot = %v, want %v", titles(now), titles(tt.want))
+				}
+			}
+		})
+	}
+}
+
+func TestSnapshotsUnderConcurrentWrites(t *testing.T) {
+	bc := collection(catalog(50))
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range 200 {
+			b := &Book{Title: "Extra", Author: "Writer"}
+			bc.AddBook(b)
+			if i%2 == 0 {
+				bc.RemoveBook(b)
+			}
+		}
+	}()
+	for range 50 {
+		snap := bc.CreateSnapshotIterator()
+		first, err := drain(snap)
+		if err != nil {
+			t.Fatal(err)
+		}
+		snap.Reset()
+		second, _ := drain(snap)
+		if !slices.Equal(first, second) {
+			t.Fatalf("snapshot changed between passes: %d then %d books", len(first), len(second))
+		}
+	}
+	wg.Wait()
+}
@@ -0,0 +1,267 @@
+package iterator
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// --- Streaming loaders ---
+// LoaderIterator reads books lazily from an io.Reader, one record per Next, so large
+// catalog dumps can be walked without building a BookCollection first.
+
+// ErrNotRewindable is reported by Reset when the underlying reader is not an io.Seeker.
+var ErrNotRewindable = errors.New("reader cannot be rewound")
+
+// RecordError describes a malformed record and where it was found.
+type RecordError struct {
+	Line int // 1-based line the record starts on
+	Err  error
+}
+
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// LoaderOptions tunes a LoaderIterator.
+type LoaderOptions struct {
+	// Tolerant skips malformed records and collects them (see Skipped) instead of
+	// stopping at the first one. I/O errors still stop the iteration.
+	Tolerant bool
+	// TitleColumn and AuthorColumn name the CSV header columns, matched case-insensitively.
+	// They default to "title" and "author".
+	TitleColumn  string
+	AuthorColumn string
+}
+
+// recordDecoder yields one book per call. It returns a *RecordError for a malformed
+// record it can continue past, io.EOF at the end, and any other error when it is stuck.
+type recordDecoder interface {
+	decode() (*Book, error)
+}
+
+// LoaderIterator is a BookIterator over records decoded from a reader.
+type LoaderIterator struct {
+	r          io.Reader
+	opts       LoaderOptions
+	newDecoder func(io.Reader, LoaderOptions) recordDecoder
+
+	dec     recordDecoder
+	peeked  *Book
+	done    bool
+	err     error
+	skipped []*RecordError
+}
+
+// NewCSVBookIterator reads books from CSV with a header row. Extra columns are ignored.
+func NewCSVBookIterator(r io.Reader, opts LoaderOptions) *LoaderIterator {
+	return newLoaderIterator(r, opts, newCSVDecoder)
+}
+
+// NewJSONLBookIterator reads books from JSON Lines, one {"title": ..., "author": ...}
+// object per line. Blank lines are ignored.
+func NewJSONLBookIterator(r io.Reader, opts LoaderOptions) *LoaderIterator {
+	return newLoaderIterator(r, opts, newJSONLDecoder)
+}
+
+func newLoaderIterator(r io.Reader, opts LoaderOptions, newDecoder func(io.Reader, LoaderOptions) recordDecoder) *LoaderIterator {
+	if opts.TitleColumn == "" {
+		opts.TitleColumn = "title"
+	}
+	if opts.AuthorColumn == "" {
+		opts.AuthorColumn = "author"
+	}
+	return &LoaderIterator{r: r, opts: opts, newDecoder: newDecoder, dec: newDecoder(r, opts)}
+}
+
+func (li *LoaderIterator) HasNext() bool {
+	for li.peeked == nil && !li.done {
+		book, err := li.dec.decode()
+		var recErr *RecordError
+		switch {
+		case err == nil:
+			li.peeked = book
+		case errors.As(err, &recErr) && li.opts.Tolerant:
+			li.skipped = append(li.skipped, recErr)
+		case errors.Is(err, io.EOF):
+			li.done = true
+		default:
+			li.err, li.done = err, true
+		}
+	}
+	return li.peeked != nil
+}
+
+func (li *LoaderIterator) Next() *Book {
+	if !li.HasNext() {
+		return nil
+	}
+	book := li.peeked
+	li.peeked = nil
+	return book
+}
+
+// Reset rewinds the reader if it is an io.Seeker; otherwise Err reports ErrNotRewindable.
+func (li *LoaderIterator) Reset() {
+	li.peeked, li.skipped = nil, nil
+	seeker, ok := li.r.(io.Seeker)
+	if !ok {
+		li.err, li.done = ErrNotRewindable, true
+		return
+	}
+	if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+		li.err, li.done = err, true
+		return
+	}
+	li.err, li.done = nil, false
+	li.dec = li.newDecoder(li.r, li.opts)
+}
+
+// Err reports the malformed record (strict mode) or I/O error that stopped the iteration.
+func (li *LoaderIterator) Err() error {
+	return li.err
+}
+
+// Skipped returns the malformed records passed over in tolerant mode so far.
+func (li *LoaderIterator) Skipped() []*RecordError {
+	return li.skipped
+}
+
+// --- CSV ---
+
+type csvDecoder struct {
+	r      *csv.Reader
+	opts   LoaderOptions
+	width  int // Number of header fields; zero until the header is read
+	title  int // Column indexes taken from the header
+	author int
+}
+
+func newCSVDecoder(r io.Reader, opts LoaderOptions) recordDecoder {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1 // Ragged rows are checked against the header instead
+	cr.ReuseRecord = true
+	return &csvDecoder{r: cr, opts: opts}
+}
+
+func (d *csvDecoder) decode() (*Book, error) {
+	if d.width == 0 {
+		if err := d.readHeader(); err != nil {
+			return nil, err
+		}
+	}
+	record, err := d.r.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, &RecordError{Line: parseErr.StartLine, Err: parseErr.Err}
+		}
+		return nil, err
+	}
+	line, _ := d.r.FieldPos(0)
+	if len(record) != d.width {
+		return nil, &RecordError{Line: line, Err: fmt.Errorf("got %d fields, header has %d", len(record), d.width)}
+	}
+	book := &Book{
+		Title:  strings.TrimSpace(record[d.title]),
+		Author: strings.TrimSpace(record[d.author]),
+	}
+	if err := validateBook(book); err != nil {
+		return nil, &RecordError{Line: line, Err: err}
+	}
+	return book, nil
+}
+
+// readHeader maps the header row. A missing or incomplete header is not a record
+// error: nothing after it could be read correctly, so it stops even a tolerant loader.
+// Names are compared case-insensitively, so "Title" and "title" in one header are
+// rejected as duplicates rather than silently picking one of them. Blank names are
+// allowed, as spreadsheets often export unnamed trailing columns.
+func (d *csvDecoder) readHeader() error {
+	header, err := d.r.Read()
+	if errors.Is(err, io.EOF) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("read CSV header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimPrefix(name, "\ufeff") // Spreadsheet exports often start with a BOM
+		key := strings.ToLower(strings.TrimSpace(name))
+		if key == "" {
+			continue
+		}
+		if prev, dup := columns[key]; dup {
+			return fmt.Errorf("CSV header repeats column %q (columns %d and %d)", strings.TrimSpace(name), prev+1, i+1)
+		}
+		columns[key] = i
+	}
+	title, ok := columns[strings.ToLower(d.opts.TitleColumn)]
+	if !ok {
+		return fmt.Errorf("CSV header has no %q column", d.opts.TitleColumn)
+	}
+	author, ok := columns[strings.ToLower(d.opts.AuthorColumn)]
+	if !ok {
+		return fmt.Errorf("CSV header has no %q column", d.opts.AuthorColumn)
+	}
+	d.width, d.title, d.author = len(header), title, author
+	return nil
+}
+
+// --- JSON Lines ---
+
+type jsonlDecoder struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func newJSONLDecoder(r io.Reader, _ LoaderOptions) recordDecoder {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	return &jsonlDecoder{scanner: scanner}
+}
+
+func (d *jsonlDecoder) decode() (*Book, error) {
+	for d.scanner.Scan() {
+		d.line++
+		text := strings.TrimSpace(d.scanner.Text())
+		if text == "" {
+			continue
+		}
+		var record struct {
+			Title  string `json:"title"`
+			Author string `json:"author"`
+		}
+		if err := json.Unmarshal([]byte(text), &record); err != nil {
+			return nil, &RecordError{Line: d.line, Err: err}
+		}
+		book := &Book{Title: strings.TrimSpace(record.Title), Author: strings.TrimSpace(record.Author)}
+		if err := validateBook(book); err != nil {
+			return nil, &RecordError{Line: d.line, Err: err}
+		}
+		return book, nil
+	}
+	if err := d.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("after line %d: %w", d.line, err)
+	}
+	return nil, io.EOF
+}
+
+func validateBook(book *Book) error {
+	switch {
+	case book.Title == "":
+		return errors.New("missing title")
+	case book.Author == "":
+		return errors.New("missing author")
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package iterator
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestCSVBookIterator(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		opts        LoaderOptions
+		wantTitles  []string
+		wantSkipped []int  // Lines of skipped records
+		wantErr     string // Substring of Err; empty for none
+	}{
+		{"basic", "title,author\nDune,Herbert\nEmma,Austen\n", LoaderOptions{}, []string{"Dune", "Emma"}, nil, ""},
+		{"mixed case and extra columns", "\ufeffIsbn, Title ,AUTHOR\n1,Dune,Herbert\n", LoaderOptions{}, []string{"Dune"}, nil, ""},
+		{"unnamed trailing columns", "title,author,,\nDune,Herbert,,\n", LoaderOptions{}, []string{"Dune"}, nil, ""},
+		{"custom columns", "name,writer\nDune,Herbert\n", LoaderOptions{TitleColumn: "Name", AuthorColumn: "writer"}, []string{"Dune"}, nil, ""},
+		{"duplicate header", "title,author,title\nDune,Herbert,Dune\n", LoaderOptions{}, nil, nil, `repeats column "title" (columns 1 and 3)`},
+		{"case-colliding header", "Title,author,TITLE\nDune,Herbert,Dune\n", LoaderOptions{Tolerant: true}, nil, nil, `repeats column "TITLE"`},
+		{"missing column", "title,writer\nDune,Herbert\n", LoaderOptions{}, nil, nil, `no "author" column`},
+		{"strict stops at ragged row", "title,author\nDune\nEmma,Austen\n", LoaderOptions{}, nil, nil, "line 2: got 1 fields, header has 2"},
+		{"tolerant skips bad rows", "title,author\nDune\n,Nobody\nEmma,Austen\n", LoaderOptions{Tolerant: true}, []string{"Emma"}, []int{2, 3}, ""},
+		{"empty input", "", LoaderOptions{}, nil, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it := NewCSVBookIterator(strings.NewReader(tt.input), tt.opts)
+			checkLoader(t, it, tt.wantTitles, tt.wantSkipped, tt.wantErr)
+		})
+	}
+}
+
+func TestJSONLBookIterator(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		opts        LoaderOptions
+		wantTitles  []string
+		wantSkipped []int
+		wantErr     string
+	}{
+		{"basic", `{"title":"Dune","author":"Herbert"}` + "\n\n" + `{"title":"Emma","author":"Austen"}`, LoaderOptions{}, []string{"Dune", "Emma"}, nil, ""},
+		{"strict stops at bad json", `{"title":"Dune","author":"Herbert"}` + "\n{oops\n", LoaderOptions{}, []string{"Dune"}, nil, "line 2:"},
+		{"tolerant skips", "{oops\n" + `{"title":"Emma"}` + "\n" + `{"title":"Dune","author":"Herbert"}`, LoaderOptions{Tolerant: true}, []string{"Dune"}, []int{1, 2}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it := NewJSONLBookIterator(strings.NewReader(tt.input), tt.opts)
+			checkLoader(t, it, tt.wantTitles, tt.wantSkipped, tt.wantErr)
+		})
+	}
+}
+
+func checkLoader(t *testing.T, it *LoaderIterator, wantTitles []string, wantSkipped []int, wantErr string) {
+	t.Helper()
+	books, err := drain(it)
+	var titles []string
+	for _, b := range books {
+		titles = append(titles, b.Title)
+	}
+	if strings.Join(titles, "|") != strings.Join(wantTitles, "|") {
+		t.Errorf("titles = %q, want %q", titles, wantTitles)
+	}
+	switch {
+	case wantErr == "" && err != nil:
+		t.Errorf("Err = %v, want nil", err)
+	case wantErr != "" && (err == nil || !strings.Contains(err.Error(), wantErr)):
+		t.Errorf("Err = %v, want it to contain %q", err, wantErr)
+	}
+	var lines []int
+	for _, skipped := range it.Skipped() {
+		lines = append(lines, skipped.Line)
+	}
+	if len(lines) != len(wantSkipped) {
+		t.Fatalf("skipped lines = %v, want %v", lines, wantSkipped)
+	}
+	for i := range lines {
+		if lines[i] != wantSkipped[i] {
+			t.Errorf("skipped lines = %v, want %v", lines, wantSkipped)
+		}
+	}
+}
+
+func TestLoaderReset(t *testing.T) {
+	input := "title,author\nDune,Herbert\n"
+	it := NewCSVBookIterator(strings.NewReader(input), LoaderOptions{})
+	first, _ := drain(it)
+	it.Reset()
+	second, err := drain(it)
+	if err != nil || len(first) != 1 || len(second) != 1 {
+		t.Errorf("after Reset read %d then %d books, err %v; want 1, 1, nil", len(first), len(second), err)
+	}
+
+	it = NewCSVBookIterator(io.MultiReader(strings.NewReader(input)), LoaderOptions{})
+	it.Reset()
+	if !errors.Is(it.Err(), ErrNotRewindable) {
+		t.Errorf("Reset on a plain reader: Err = %v, want ErrNotRewindable", it.Err())
+	}
+}
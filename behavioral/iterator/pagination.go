@@ -0,0 +1,218 @@
+package iterator
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// --- Paginated iterator over a remote-like source ---
+
+// Page is one page of results. An empty NextCursor marks the last page.
+type Page struct {
+	Books      []*Book
+	NextCursor string
+}
+
+// PageFetcher is a cursor-paginated book source such as a catalog API.
+// The empty cursor requests the first page.
+type PageFetcher interface {
+	FetchPage(ctx context.Context, cursor string, limit int) (Page, error)
+}
+
+// PaginationConfig tunes a PaginatedIterator.
+type PaginationConfig struct {
+	PageSize int    // Books requested per page (default 50)
+	Prefetch int    // Pages fetched ahead in the background; 0 fetches on demand
+	Resume   string // Token from Cursor() to continue an earlier scan
+}
+
+// position is the decoded form of the opaque cursor token: the page a book is on
+// and its offset within that page.
+type position struct {
+	Page   string `json:"p,omitempty"`
+	Offset int    `json:"o,omitempty"`
+}
+
+func (p position) token() string {
+	data, _ := json.Marshal(p)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func parseToken(token string) (position, error) {
+	var p position
+	if token == "" {
+		return p, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return p, fmt.Errorf("invalid cursor token: %w", err)
+	}
+	if err := json.Unmarshal(data, &p); err != nil || p.Offset < 0 {
+		return p, fmt.Errorf("invalid cursor token %q", token)
+	}
+	return p, nil
+}
+
+type fetchedPage struct {
+	cursor string // Cursor the page was requested with
+	page   Page
+	err    error
+}
+
+// PaginatedIterator is a BookIterator that pulls books page by page from a PageFetcher.
+// Fetch failures end the iteration and are reported by Err. Cursor returns an opaque
+// token that resumes a new iterator at the next unread book.
+// Call Stop when abandoning it early, so background prefetching ends.
+type PaginatedIterator struct {
+	ctx     context.Context
+	fetcher PageFetcher
+	cfg     PaginationConfig
+	start   position
+
+	fetchCtx context.Context // ctx of the current scan, cancelled by Stop
+	cancel   context.CancelFunc
+	stopped  chan struct{}    // Closed when the prefetcher has exited
+	pages    chan fetchedPage // Filled by the prefetcher, nil when fetching on demand
+	next     string           // Cursor of the page after the current one
+	hasMore  bool             // More pages follow the current one
+
+	current fetchedPage
+	offset  int
+	err     error
+}
+
+func NewPaginatedIterator(ctx context.Context, fetcher PageFetcher, cfg PaginationConfig) (*PaginatedIterator, error) {
+	if cfg.PageSize <= 0 {
+		cfg.PageSize = 50
+	}
+	start, err := parseToken(cfg.Resume)
+	if err != nil {
+		return nil, err
+	}
+	it := &PaginatedIterator{ctx: ctx, fetcher: fetcher, cfg: cfg, start: start}
+	it.Reset()
+	return it, nil
+}
+
+func (pi *PaginatedIterator) HasNext() bool {
+	for pi.err == nil && pi.offset >= len(pi.current.page.Books) {
+		if !pi.loadNextPage() {
+			return false
+		}
+	}
+	return pi.err == nil
+}
+
+func (pi *PaginatedIterator) Next() *Book {
+	if !pi.HasNext() {
+		return nil
+	}
+	book := pi.current.page.Books[pi.offset]
+	pi.offset++
+	return book
+}
+
+// Reset restarts the scan from where the iterator was created (the Resume token, if any).
+func (pi *PaginatedIterator) Reset() {
+	pi.Stop()
+	ctx, cancel := context.WithCancel(pi.ctx)
+	pi.fetchCtx, pi.cancel = ctx, cancel
+	pi.current = fetchedPage{}
+	pi.offset = 0
+	pi.err = nil
+	pi.hasMore = true
+	pi.next = pi.start.Page
+	pi.pages, pi.stopped = nil, nil
+	if pi.cfg.Prefetch > 0 {
+		// One page is held by the prefetcher while it waits, so the buffer holds the rest.
+		pi.pages = make(chan fetchedPage, pi.cfg.Prefetch-1)
+		pi.stopped = make(chan struct{})
+		go pi.prefetch(ctx, pi.start.Page, pi.pages, pi.stopped)
+	}
+
+	// Skip the books that were already read before the Resume token was issued.
+	if pi.start.Offset > 0 && pi.loadNextPage() {
+		pi.offset = min(pi.start.Offset, len(pi.current.page.Books))
+	}
+}
+
+func (pi *PaginatedIterator) Err() error {
+	return pi.err
+}
+
+// Cursor returns an opaque token for the next unread book. Passing it as
+// PaginationConfig.Resume continues the scan from there.
+func (pi *PaginatedIterator) Cursor() string {
+	if pi.current.page.Books == nil && pi.offset == 0 {
+		return pi.start.token() // Nothing loaded yet
+	}
+	if pi.offset >= len(pi.current.page.Books) && pi.current.page.NextCursor != "" {
+		return position{Page: pi.current.page.NextCursor}.token()
+	}
+	return position{Page: pi.current.cursor, Offset: pi.offset}.token()
+}
+
+// Stop cancels background prefetching and waits for an in-flight fetch to return.
+// The iterator can be reused after Reset.
+func (pi *PaginatedIterator) Stop() {
+	if pi.cancel != nil {
+		pi.cancel()
+	}
+	if pi.stopped != nil {
+		<-pi.stopped
+	}
+}
+
+// loadNextPage makes the following page current. It returns false at the end or on error;
+// a scan cut off by cancellation ends with the context's error in both modes.
+func (pi *PaginatedIterator) loadNextPage() bool {
+	if !pi.hasMore {
+		return false
+	}
+	var fetched fetchedPage
+	if pi.pages != nil {
+		var ok bool
+		fetched, ok = <-pi.pages
+		if !ok {
+			// The prefetcher was cancelled before it delivered the last page.
+			fetched = fetchedPage{cursor: pi.next, err: pi.fetchCtx.Err()}
+			if fetched.err == nil {
+				fetched.err = context.Canceled
+			}
+		}
+	} else if err := pi.fetchCtx.Err(); err != nil {
+		fetched = fetchedPage{cursor: pi.next, err: err}
+	} else {
+		page, err := pi.fetcher.FetchPage(pi.fetchCtx, pi.next, pi.cfg.PageSize)
+		fetched = fetchedPage{cursor: pi.next, page: page, err: err}
+	}
+
+	if fetched.err != nil {
+		pi.err = fmt.Errorf("fetch page %q: %w", fetched.cursor, fetched.err)
+		return false
+	}
+	pi.current = fetched
+	pi.offset = 0
+	pi.next, pi.hasMore = fetched.page.NextCursor, fetched.page.NextCursor != ""
+	return true
+}
+
+// prefetch fetches pages ahead of the consumer until the last page, an error or cancellation.
+func (pi *PaginatedIterator) prefetch(ctx context.Context, cursor string, pages chan<- fetchedPage, stopped chan<- struct{}) {
+	defer close(stopped)
+	defer close(pages)
+	for {
+		page, err := pi.fetcher.FetchPage(ctx, cursor, pi.cfg.PageSize)
+		select {
+		case pages <- fetchedPage{cursor: cursor, page: page, err: err}:
+		case <-ctx.Done():
+			return
+		}
+		if err != nil || page.NextCursor == "" {
+			return
+		}
+		cursor = page.NextCursor
+	}
+}
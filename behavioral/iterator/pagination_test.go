@@ -0,0 +1,175 @@
+package iterator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func catalog(n int) []*Book {
+	books := make([]*Book, n)
+	for i := range books {
+		books[i] = &Book{Title: fmt.Sprintf("Book %03d", i), Author: "Author"}
+	}
+	return books
+}
+
+// drain reads every remaining book and returns them with the iterator's error.
+func drain(it BookIterator) ([]*Book, error) {
+	var books []*Book
+	for it.HasNext() {
+		books = append(books, it.Next())
+	}
+	return books, it.Err()
+}
+
+func TestPaginatedIterator(t *testing.T) {
+	books := catalog(23)
+	errBoom := errors.New("boom")
+	tests := []struct {
+		name      string
+		pageSize  int
+		prefetch  int
+		fail      func(call int, cursor string) error
+		wantBooks int
+		wantErr   error
+		wantCalls int
+	}{
+		{"on demand", 5, 0, nil, 23, nil, 5},
+		{"prefetch one", 5, 1, nil, 23, nil, 5},
+		{"prefetch deep", 5, 4, nil, 23, nil, 5},
+		{"exact pages", 23, 2, nil, 23, nil, 1},
+		{"fails on third page on demand", 5, 0, failOnCall(3, errBoom), 10, errBoom, 3},
+		{"fails on third page prefetching", 5, 3, failOnCall(3, errBoom), 10, errBoom, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetcher := NewFakePageFetcher(books, 0)
+			fetcher.Fail = tt.fail
+			it, err := NewPaginatedIterator(context.Background(), fetcher, PaginationConfig{PageSize: tt.pageSize, Prefetch: tt.prefetch})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer it.Stop()
+			got, err := drain(it)
+			if len(got) != tt.wantBooks {
+				t.Errorf("read %d books, want %d", len(got), tt.wantBooks)
+			}
+			for i, b := range got {
+				if b != books[i] {
+					t.Fatalf("book %d = %v, want %v", i, b, books[i])
+				}
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Err = %v, want %v", err, tt.wantErr)
+			}
+			it.Stop()
+			if fetcher.Calls() != tt.wantCalls {
+				t.Errorf("fetched %d pages, want %d", fetcher.Calls(), tt.wantCalls)
+			}
+		})
+	}
+}
+
+func failOnCall(n int, err error) func(int, string) error {
+	return func(call int, _ string) error {
+		if call == n {
+			return err
+		}
+		return nil
+	}
+}
+
+func TestPaginatedIteratorResume(t *testing.T) {
+	books := catalog(17)
+	for _, prefetch := range []int{0, 2} {
+		for stopAfter := 0; stopAfter <= len(books); stopAfter++ {
+			t.Run(fmt.Sprintf("prefetch %d after %d", prefetch, stopAfter), func(t *testing.T) {
+				fetcher := NewFakePageFetcher(books, 0)
+				cfg := PaginationConfig{PageSize: 4, Prefetch: prefetch}
+				first, err := NewPaginatedIterator(context.Background(), fetcher, cfg)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for range stopAfter {
+					first.Next()
+				}
+				cfg.Resume = first.Cursor()
+				first.Stop()
+
+				second, err := NewPaginatedIterator(context.Background(), fetcher, cfg)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer second.Stop()
+				rest, err := drain(second)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(rest) != len(books)-stopAfter || (len(rest) > 0 && rest[0] != books[stopAfter]) {
+					t.Errorf("resumed with %d books starting at %v, want %d starting at %v",
+						len(rest), rest, len(books)-stopAfter, books[min(stopAfter, len(books)-1)])
+				}
+			})
+		}
+	}
+}
+
+func TestPaginatedIteratorCancellation(t *testing.T) {
+	books := catalog(100)
+	for _, prefetch := range []int{0, 1, 3} {
+		t.Run(fmt.Sprintf("prefetch %d", prefetch), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			fetcher := NewFakePageFetcher(books, 2*time.Millisecond)
+			it, err := NewPaginatedIterator(ctx, fetcher, PaginationConfig{PageSize: 5, Prefetch: prefetch})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer it.Stop()
+			for range 5 {
+				it.Next()
+			}
+			cancel()
+			rest, err := drain(it)
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("Err after cancel = %v, want context.Canceled", err)
+			}
+			if 5+len(rest) == len(books) {
+				t.Error("scan completed although it was cancelled")
+			}
+		})
+	}
+}
+
+func TestPaginatedIteratorStop(t *testing.T) {
+	for _, prefetch := range []int{0, 2} {
+		t.Run(fmt.Sprintf("prefetch %d", prefetch), func(t *testing.T) {
+			fetcher := NewFakePageFetcher(catalog(50), time.Millisecond)
+			it, err := NewPaginatedIterator(context.Background(), fetcher, PaginationConfig{PageSize: 5, Prefetch: prefetch})
+			if err != nil {
+				t.Fatal(err)
+			}
+			it.Next()
+			it.Stop()
+			if _, err := drain(it); !errors.Is(err, context.Canceled) {
+				t.Errorf("Err after Stop = %v, want context.Canceled", err)
+			}
+
+			it.Reset()
+			got, err := drain(it)
+			if err != nil || len(got) != 50 {
+				t.Errorf("after Reset read %d books, err %v; want 50, nil", len(got), err)
+			}
+		})
+	}
+}
+
+func TestParseTokenRejectsGarbage(t *testing.T) {
+	for _, token := range []string{"!!", "bm90IGpzb24", position{Offset: -1}.token()} {
+		if _, err := NewPaginatedIterator(context.Background(), NewFakePageFetcher(nil, 0), PaginationConfig{Resume: token}); err == nil {
+			t.Errorf("token %q accepted", token)
+		}
+	}
+}
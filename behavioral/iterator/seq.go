@@ -0,0 +1,123 @@
+package iterator
+
+import "iter"
+
+// --- Range-over-func support (Go 1.23+) ---
+// All and Backward let new code use `for book := range collection.All()`,
+// while the adapters below bridge to and from the classic BookIterator protocol.
+
+// All yields the books in insertion order. Each range works on a snapshot taken
+// when it starts, so the collection may be modified from inside the loop.
+func (bc *BookCollection) All() iter.Seq[*Book] {
+	return func(yield func(*Book) bool) {
+		for _, book := range bc.snapshot() {
+			if !yield(book) {
+				return
+			}
+		}
+	}
+}
+
+// Backward yields the books from the most recently added to the first, from a snapshot.
+func (bc *BookCollection) Backward() iter.Seq[*Book] {
+	return func(yield func(*Book) bool) {
+		books := bc.snapshot()
+		for i := len(books) - 1; i >= 0; i-- {
+			if !yield(books[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Seq adapts a classic BookIterator to an iter.Seq. It continues from the iterator's
+// current position and advances it, so breaking out of the loop leaves the iterator
+// right after the last yielded book. Seq cannot report why the iterator stopped:
+// check it.Err after the loop, or use Seq2.
+func Seq(it BookIterator) iter.Seq[*Book] {
+	return func(yield func(*Book) bool) {
+		for it.HasNext() {
+			if !yield(it.Next()) {
+				return
+			}
+		}
+	}
+}
+
+// Seq2 is Seq for iterators that can fail. It yields every book with a nil error and,
+// if the iterator stopped early, a final nil book with the iterator's Err.
+func Seq2(it BookIterator) iter.Seq2[*Book, error] {
+	return func(yield func(*Book, error) bool) {
+		for it.HasNext() {
+			if !yield(it.Next(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// SeqIterator adapts an iter.Seq to the classic BookIterator protocol using iter.Pull.
+// Call Stop when abandoning it before the end, so the underlying sequence can clean up.
+type SeqIterator struct {
+	seq     iter.Seq[*Book]
+	next    func() (*Book, bool)
+	stop    func()
+	peeked  *Book // Book fetched by HasNext but not yet returned by Next
+	hasPeek bool
+	done    bool
+}
+
+// FromSeq wraps seq as a BookIterator.
+func FromSeq(seq iter.Seq[*Book]) *SeqIterator {
+	it := &SeqIterator{seq: seq}
+	it.Reset()
+	return it
+}
+
+func (si *SeqIterator) HasNext() bool {
+	if si.hasPeek {
+		return true
+	}
+	if si.done {
+		return false
+	}
+	book, ok := si.next()
+	if !ok {
+		si.done = true
+		si.stop()
+		return false
+	}
+	si.peeked, si.hasPeek = book, true
+	return true
+}
+
+func (si *SeqIterator) Next() *Book {
+	if !si.HasNext() {
+		return nil
+	}
+	book := si.peeked
+	si.peeked, si.hasPeek = nil, false
+	return book
+}
+
+// Reset restarts the underlying sequence from the beginning.
+func (si *SeqIterator) Reset() {
+	if si.stop != nil {
+		si.stop()
+	}
+	si.next, si.stop = iter.Pull(si.seq)
+	si.peeked, si.hasPeek, si.done = nil, false, false
+}
+
+func (si *SeqIterator) Err() error {
+	return nil
+}
+
+// Stop releases the underlying sequence. The iterator reports no more books afterwards.
+func (si *SeqIterator) Stop() {
+	si.stop()
+	si.peeked, si.hasPeek, si.done = nil, false, true
+}
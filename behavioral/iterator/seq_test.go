@@ -0,0 +1,239 @@
+package iterator
+
+import (
+	"errors"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// collection returns a BookCollection holding books, in order.
+func collection(books []*Book) *BookCollection {
+	bc := NewBookCollection()
+	for _, b := range books {
+		bc.AddBook(b)
+	}
+	return bc
+}
+
+// titles lists the titles of books, for readable comparisons.
+func titles(books []*Book) []string {
+	out := make([]string, len(books))
+	for i, b := range books {
+		out[i] = b.Title
+	}
+	return out
+}
+
+// collectUntil ranges over seq and stops after limit books; a negative limit reads all.
+func collectUntil(seq iter.Seq[*Book], limit int) []*Book {
+	var got []*Book
+	if limit == 0 {
+		return nil
+	}
+	for b := range seq {
+		got = append(got, b)
+		if len(got) == limit {
+			break
+		}
+	}
+	return got
+}
+
+func TestCollectionSeqs(t *testing.T) {
+	books := catalog(4)
+	reversed := slices.Clone(books)
+	slices.Reverse(reversed)
+	tests := []struct {
+		name  string
+		books []*Book
+		seq   func(*BookCollection) iter.Seq[*Book]
+		limit int
+		want  []*Book
+	}{
+		{"all", books, (*BookCollection).All, -1, books},
+		{"all, break early", books, (*BookCollection).All, 2, books[:2]},
+		{"all, empty", nil, (*BookCollection).All, -1, nil},
+		{"backward", books, (*BookCollection).Backward, -1, reversed},
+		{"backward, break early", books, (*BookCollection).Backward, 1, reversed[:1]},
+		{"backward, empty", nil, (*BookCollection).Backward, -1, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := collectUntil(tt.seq(collection(tt.books)), tt.limit)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", titles(got), titles(tt.want))
+			}
+		})
+	}
+}
+
+func TestAllAllowsModificationInsideTheLoop(t *testing.T) {
+	books := catalog(3)
+	for name, seq := range map[string]func(*BookCollection) iter.Seq[*Book]{
+		"all": (*BookCollection).All, "backward": (*BookCollection).Backward,
+	} {
+		t.Run(name, func(t *testing.T) {
+			bc := collection(books)
+			seen := 0
+			for b := range seq(bc) {
+				seen++
+				bc.RemoveBook(b)
+				bc.AddBook(&Book{Title: "new " + b.Title})
+			}
+			if seen != 3 || bc.Len() != 3 {
+				t.Errorf("saw %d books, collection has %d; want 3 and 3", seen, bc.Len())
+			}
+		})
+	}
+}
+
+func TestSeq(t *testing.T) {
+	books := catalog(5)
+	it := collection(books).CreateIterator()
+	it.Next()
+
+	// Seq continues from the iterator's position, and a break leaves the iterator
+	// right after the last book the loop saw.
+	if got := collectUntil(Seq(it), 2); !slices.Equal(got, books[1:3]) {
+		t.Errorf("first loop got %v, want %v", titles(got), titles(books[1:3]))
+	}
+	if got := it.Next(); got != books[3] {
+		t.Errorf("Next after the loop = %v, want %v", got, books[3])
+	}
+	if got := collectUntil(Seq(it), -1); !slices.Equal(got, books[4:]) {
+		t.Errorf("second loop got %v, want %v", titles(got), titles(books[4:]))
+	}
+	if got := collectUntil(Seq(NewBookCollection().CreateIterator()), -1); len(got) != 0 {
+		t.Errorf("empty collection yielded %v", titles(got))
+	}
+}
+
+func TestSeq2(t *testing.T) {
+	books := catalog(4)
+	tests := []struct {
+		name      string
+		it        func() BookIterator
+		modify    func(bc *BookCollection) // Called after the first book, if set
+		wantBooks []string
+		wantErr   error
+		wantMsg   string
+	}{
+		{"ends cleanly", func() BookIterator { return collection(books).CreateIterator() }, nil,
+			titles(books), nil, ""},
+		{"empty", func() BookIterator { return NewBookCollection().CreateIterator() }, nil, nil, nil, ""},
+		{"loader error", func() BookIterator {
+			return NewCSVBookIterator(strings.NewReader("title,author\nDune,Herbert\nEmma\n"), LoaderOptions{})
+		}, nil, []string{"Dune"}, nil, "line 3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			var gotErr error
+			for b, err := range Seq2(tt.it()) {
+				if err != nil {
+					if b != nil {
+						t.Errorf("error %v came with book %v", err, b)
+					}
+					gotErr = err
+					continue
+				}
+				got = append(got, b.Title)
+			}
+			if !slices.Equal(got, tt.wantBooks) {
+				t.Errorf("books = %v, want %v", got, tt.wantBooks)
+			}
+			if tt.wantMsg == "" && gotErr != nil || tt.wantMsg != "" && (gotErr == nil || !strings.Contains(gotErr.Error(), tt.wantMsg)) {
+				t.Errorf("error = %v, want %q", gotErr, tt.wantMsg)
+			}
+		})
+	}
+
+	t.Run("concurrent modification", func(t *testing.T) {
+		bc := collection(books)
+		var got []*Book
+		var gotErr error
+		for b, err := range Seq2(bc.CreateIterator()) {
+			if err != nil {
+				gotErr = err
+				break
+			}
+			got = append(got, b)
+			if len(got) == 1 {
+				bc.AddBook(&Book{Title: "Late"})
+			}
+		}
+		if len(got) != 1 || !errors.Is(gotErr, ErrConcurrentModification) {
+			t.Errorf("read %d books, err %v; want 1 and ErrConcurrentModification", len(got), gotErr)
+		}
+	})
+
+	t.Run("break skips the error", func(t *testing.T) {
+		it := NewCSVBookIterator(strings.NewReader("title,author\nDune,Herbert\nEmma\n"), LoaderOptions{})
+		for _, err := range Seq2(it) {
+			if err != nil {
+				t.Fatalf("got %v after breaking", err)
+			}
+			break
+		}
+	})
+}
+
+func TestFromSeq(t *testing.T) {
+	books := catalog(3)
+	it := FromSeq(collection(books).All())
+	got, err := drain(it)
+	if err != nil || !slices.Equal(got, books) {
+		t.Fatalf("drain = %v, %v; want %v", titles(got), err, titles(books))
+	}
+	if it.HasNext() || it.Next() != nil {
+		t.Error("exhausted iterator still has books")
+	}
+
+	// HasNext peeks without consuming.
+	it.Reset()
+	if !it.HasNext() || !it.HasNext() || it.Next() != books[0] {
+		t.Error("HasNext consumed a book")
+	}
+
+	// Round trip through both adapters keeps the order.
+	if got := collectUntil(Seq(FromSeq(collection(books).Backward())), -1); !slices.Equal(got, []*Book{books[2], books[1], books[0]}) {
+		t.Errorf("Seq(FromSeq(Backward)) = %v", titles(got))
+	}
+
+	empty := FromSeq(NewBookCollection().All())
+	if empty.HasNext() || empty.Next() != nil {
+		t.Error("empty sequence has books")
+	}
+}
+
+func TestFromSeqStopReleasesTheSequence(t *testing.T) {
+	finished := false
+	seq := func(yield func(*Book) bool) {
+		defer func() { finished = true }()
+		for _, b := range catalog(10) {
+			if !yield(b) {
+				return
+			}
+		}
+	}
+	it := FromSeq(seq)
+	it.Next()
+	it.Stop()
+	if !finished {
+		t.Error("Stop left the sequence suspended")
+	}
+	if it.HasNext() {
+		t.Error("stopped iterator still has books")
+	}
+	if it.Err() != nil {
+		t.Errorf("Err = %v, want nil", it.Err())
+	}
+
+	// Reset starts over with a fresh pull.
+	it.Reset()
+	if got, _ := drain(it); len(got) != 10 {
+		t.Errorf("after Reset read %d books, want 10", len(got))
+	}
+}
@@ -1,4 +1,4 @@
-package main
+package mediator
 
 import "fmt"
 
@@ -6,20 +6,24 @@ type FreightTrain struct {
 	mediator Mediator
 }
 
-func (g *FreightTrain) arrive() {
-	if !g.mediator.canArrive(g) {
+func NewFreightTrain(mediator Mediator) *FreightTrain {
+	return &FreightTrain{mediator: mediator}
+}
+
+func (g *FreightTrain) Arrive() {
+	if !g.mediator.CanArrive(g) {
 		fmt.Println("FreightTrain: Arrival blocked, waiting")
 		return
 	}
 	fmt.Println("FreightTrain: Arrived")
 }
 
-func (g *FreightTrain) depart() {
+func (g *FreightTrain) Depart() {
 	fmt.Println("FreightTrain: Leaving")
-	g.mediator.notifyAboutDeparture()
+	g.mediator.NotifyAboutDeparture()
 }
 
-func (g *FreightTrain) permitArrival() {
+func (g *FreightTrain) PermitArrival() {
 	fmt.Println("FreightTrain: Arrival permitted")
-	g.arrive()
+	g.Arrive()
 }
@@ -1,16 +0,0 @@
-package main
-
-func main() {
-	stationManager := newStationManger()
-
-	passengerTrain := &PassengerTrain{
-		mediator: stationManager,
-	}
-	freightTrain := &FreightTrain{
-		mediator: stationManager,
-	}
-
-	passengerTrain.arrive()
-	freightTrain.arrive()
-	passengerTrain.depart()
-}
@@ -1,6 +1,6 @@
-package main
+package mediator
 
 type Mediator interface {
-	canArrive(Train) bool
-	notifyAboutDeparture()
+	CanArrive(Train) bool
+	NotifyAboutDeparture()
 }
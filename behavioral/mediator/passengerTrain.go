@@ -1,4 +1,4 @@
-package main
+package mediator
 
 import "fmt"
 
@@ -6,20 +6,24 @@ type PassengerTrain struct {
 	mediator Mediator
 }
 
-func (g *PassengerTrain) arrive() {
-	if !g.mediator.canArrive(g) {
+func NewPassengerTrain(mediator Mediator) *PassengerTrain {
+	return &PassengerTrain{mediator: mediator}
+}
+
+func (g *PassengerTrain) Arrive() {
+	if !g.mediator.CanArrive(g) {
 		fmt.Println("PassengerTrain: Arrival blocked, waiting")
 		return
 	}
 	fmt.Println("PassengerTrain: Arrived")
 }
 
-func (g *PassengerTrain) depart() {
+func (g *PassengerTrain) Depart() {
 	fmt.Println("PassengerTrain: Leaving")
-	g.mediator.notifyAboutDeparture()
+	g.mediator.NotifyAboutDeparture()
 }
 
-func (g *PassengerTrain) permitArrival() {
+func (g *PassengerTrain) PermitArrival() {
 	fmt.Println("PassengerTrain: Arrival permitted, arriving")
-	g.arrive()
+	g.Arrive()
 }
@@ -1,7 +1,7 @@
-package main
+package mediator
 
 type Train interface {
-	arrive()
-	depart()
-	permitArrival()
+	Arrive()
+	Depart()
+	PermitArrival()
 }
@@ -1,13 +1,19 @@
-package main
+package memento
 
 type Caretaker struct {
 	mementoArray []*Memento
 }
 
-func (c *Caretaker) addMemento(m *Memento) {
+func NewCaretaker() *Caretaker {
+	return &Caretaker{
+		mementoArray: make([]*Memento, 0),
+	}
+}
+
+func (c *Caretaker) AddMemento(m *Memento) {
 	c.mementoArray = append(c.mementoArray, m)
 }
 
-func (c *Caretaker) getMemento(index int) *Memento {
+func (c *Caretaker) GetMemento(index int) *Memento {
 	return c.mementoArray[index]
 }
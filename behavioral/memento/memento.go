@@ -1,9 +1,9 @@
-package main
+package memento
 
 type Memento struct {
 	state string
 }
 
-func (m *Memento) getSavedState() string {
+func (m *Memento) GetSavedState() string {
 	return m.state
 }
@@ -1,21 +1,25 @@
-package main
+package memento
 
 type Originator struct {
 	state string
 }
 
-func (e *Originator) createMemento() *Memento {
+func NewOriginator(state string) *Originator {
+	return &Originator{state: state}
+}
+
+func (e *Originator) CreateMemento() *Memento {
 	return &Memento{state: e.state}
 }
 
-func (e *Originator) restoreMemento(m *Memento) {
-	e.state = m.getSavedState()
+func (e *Originator) RestoreMemento(m *Memento) {
+	e.state = m.GetSavedState()
 }
 
-func (e *Originator) setState(state string) {
+func (e *Originator) SetState(state string) {
 	e.state = state
 }
 
-func (e *Originator) getState() string {
+func (e *Originator) GetState() string {
 	return e.state
 }
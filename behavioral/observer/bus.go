@@ -0,0 +1,186 @@
+package observer
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// --- Generic event bus ---
+// Bus is the Observer pattern without a fixed Update signature: subscribers receive
+// any event type E published on dot-separated topics such as "weather.kitchen.reading".
+//
+// Subscription patterns may use two wildcards, each as a whole segment:
+//
+//	*  matches exactly one segment ("weather.*.reading")
+//	#  matches zero or more segments ("weather.#")
+
+// Subscription is the handle returned when subscribing. Unsubscribe is idempotent
+// and safe to call from inside a handler. Once it returns, no new call to the handler
+// starts, not even from a Publish that is already under way.
+type Subscription struct {
+	once   sync.Once
+	cancel func()
+}
+
+func (s *Subscription) Unsubscribe() {
+	s.once.Do(s.cancel)
+}
+
+// Handler receives the events published on topics matching its pattern.
+type Handler[E any] func(topic string, event E)
+
+// HandlerPanicError reports a handler that panicked. The panic was recovered and
+// the remaining handlers still received the event.
+type HandlerPanicError struct {
+	Topic   string
+	Pattern string
+	Value   any
+	Stack   []byte
+}
+
+func (e *HandlerPanicError) Error() string {
+	return fmt.Sprintf("handler for %q panicked on %q: %v", e.Pattern, e.Topic, e.Value)
+}
+
+type busSubscriber[E any] struct {
+	pattern  string
+	segments []string
+	handler  Handler[E]
+	removed  atomic.Bool // Set by Unsubscribe; skips deliveries already under way
+}
+
+// Bus delivers events synchronously to every matching subscriber, in subscription order.
+// It is safe for concurrent use; handlers run without any bus lock held.
+type Bus[E any] struct {
+	mu     sync.RWMutex
+	subs   map[uint64]*busSubscriber[E]
+	nextID uint64
+}
+
+func NewBus[E any]() *Bus[E] {
+	return &Bus[E]{subs: make(map[uint64]*busSubscriber[E])}
+}
+
+// Subscribe registers handler for the topics matching pattern.
+func (b *Bus[E]) Subscribe(pattern string, handler Handler[E]) (*Subscription, error) {
+	segments, err := splitTopic(pattern, true)
+	if err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	id := b.nextID
+	b.nextID++
+	sub := &busSubscriber[E]{pattern: pattern, segments: segments, handler: handler}
+	b.subs[id] = sub
+	return &Subscription{cancel: func() {
+		sub.removed.Store(true)
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subs, id)
+	}}, nil
+}
+
+// Publish delivers event to the subscribers whose pattern matches topic and reports
+// how many were called. Subscribers added while it runs miss the event. Panicking
+// handlers are isolated and returned as a joined error of *HandlerPanicError values.
+func (b *Bus[E]) Publish(topic string, event E) (int, error) {
+	segments, err := splitTopic(topic, false)
+	if err != nil {
+		return 0, err
+	}
+	var (
+		called int
+		errs   []error
+	)
+	for _, sub := range b.match(segments) {
+		if sub.removed.Load() {
+			continue
+		}
+		called++
+		if err := deliver(sub, topic, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return called, errors.Join(errs...)
+}
+
+// Len reports the number of active subscriptions.
+func (b *Bus[E]) Len() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subs)
+}
+
+// match returns the matching subscribers ordered by subscription id.
+func (b *Bus[E]) match(topic []string) []*busSubscriber[E] {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	var ids []uint64
+	for id, sub := range b.subs {
+		if matchTopic(sub.segments, topic) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	matched := make([]*busSubscriber[E], len(ids))
+	for i, id := range ids {
+		matched[i] = b.subs[id]
+	}
+	return matched
+}
+
+func deliver[E any](sub *busSubscriber[E], topic string, event E) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &HandlerPanicError{Topic: topic, Pattern: sub.pattern, Value: r, Stack: debug.Stack()}
+		}
+	}()
+	sub.handler(topic, event)
+	return nil
+}
+
+// splitTopic validates a topic (or, with wildcards allowed, a pattern) and splits it into segments.
+func splitTopic(topic string, wildcards bool) ([]string, error) {
+	if topic == "" {
+		return nil, errors.New("empty topic")
+	}
+	segments := strings.Split(topic, ".")
+	for _, seg := range segments {
+		switch {
+		case seg == "":
+			return nil, fmt.Errorf("topic %q has an empty segment", topic)
+		case seg == "*" || seg == "#":
+			if !wildcards {
+				return nil, fmt.Errorf("cannot publish to wildcard topic %q", topic)
+			}
+		case strings.ContainsAny(seg, "*#"):
+			return nil, fmt.Errorf("topic %q: wildcards must be whole segments", topic)
+		}
+	}
+	return segments, nil
+}
+
+func matchTopic(pattern, topic []string) bool {
+	if len(pattern) == 0 {
+		return len(topic) == 0
+	}
+	switch pattern[0] {
+	case "#":
+		for i := 0; i <= len(topic); i++ {
+			if matchTopic(pattern[1:], topic[i:]) {
+				return true
+			}
+		}
+		return false
+	case "*":
+		return len(topic) > 0 && matchTopic(pattern[1:], topic[1:])
+	default:
+		return len(topic) > 0 && topic[0] == pattern[0] && matchTopic(pattern[1:], topic[1:])
+	}
+}
@@ -0,0 +1,175 @@
+package observer
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBusMatching(t *testing.T) {
+	tests := []struct {
+		pattern string
+		topic   string
+		want    bool
+	}{
+		{"weather.kitchen.reading", "weather.kitchen.reading", true},
+		{"weather.kitchen.reading", "weather.garden.reading", false},
+		{"weather.*.reading", "weather.kitchen.reading", true},
+		{"weather.*.reading", "weather.reading", false},
+		{"weather.*.reading", "weather.a.b.reading", false},
+		{"weather.*", "weather", false},
+		{"*", "weather", true},
+		{"*", "weather.kitchen", false},
+		{"weather.#", "weather.kitchen.reading", true},
+		{"weather.#", "weather", true}, // # matches zero segments
+		{"weather.#", "weathers", false},
+		{"weather.#.reading", "weather.reading", true},
+		{"weather.#.reading", "weather.a.b.c.reading", true},
+		{"weather.#.reading", "weather.a.b.c.alert", false},
+		{"#", "anything.at.all", true},
+		{"#.reading", "reading", true},
+		{"#.*", "weather", true},
+		{"#.*.#", "a.b.c", true},
+		{"*.#.*", "weather", false},
+		{"weather.#.#", "weather", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" on "+tt.topic, func(t *testing.T) {
+			bus := NewBus[int]()
+			got := false
+			if _, err := bus.Subscribe(tt.pattern, func(string, int) { got = true }); err != nil {
+				t.Fatal(err)
+			}
+			n, err := bus.Publish(tt.topic, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want || (n == 1) != tt.want {
+				t.Errorf("delivered = %t (count %d), want %t", got, n, tt.want)
+			}
+		})
+	}
+}
+
+func TestBusRejectsBadTopics(t *testing.T) {
+	bus := NewBus[int]()
+	for _, pattern := range []string{"", "weather..reading", "weather.", "weather.kit*", "we#ther"} {
+		if _, err := bus.Subscribe(pattern, func(string, int) {}); err == nil {
+			t.Errorf("Subscribe(%q) succeeded", pattern)
+		}
+	}
+	for _, topic := range []string{"", "weather.*", "#", "a..b"} {
+		if _, err := bus.Publish(topic, 1); err == nil {
+			t.Errorf("Publish(%q) succeeded", topic)
+		}
+	}
+	if bus.Len() != 0 {
+		t.Errorf("Len = %d after rejected subscriptions", bus.Len())
+	}
+}
+
+func TestBusIsolatesPanics(t *testing.T) {
+	bus := NewBus[string]()
+	var got []string
+	for _, name := range []string{"first", "panics", "last", "panics too"} {
+		bus.Subscribe("alerts.#", func(topic, event string) {
+			if strings.HasPrefix(name, "panics") {
+				panic(name + " on " + event)
+			}
+			got = append(got, name)
+		})
+	}
+	n, err := bus.Publish("alerts.frost", "cold")
+	if n != 4 {
+		t.Errorf("called %d handlers, want 4", n)
+	}
+	if !slices.Equal(got, []string{"first", "last"}) {
+		t.Errorf("healthy handlers got %v, want [first last]", got)
+	}
+	var panics []string
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var p *HandlerPanicError
+		if !errors.As(e, &p) {
+			t.Fatalf("error %v is not a *HandlerPanicError", e)
+		}
+		if p.Topic != "alerts.frost" || p.Pattern != "alerts.#" || len(p.Stack) == 0 {
+			t.Errorf("panic error = %+v", p)
+		}
+		panics = append(panics, p.Value.(string))
+	}
+	if want := []string{"panics on cold", "panics too on cold"}; !slices.Equal(panics, want) {
+		t.Errorf("panics = %v, want %v", panics, want)
+	}
+
+	// The panicking handlers stay subscribed and the bus keeps working.
+	got = nil
+	if n, _ := bus.Publish("alerts.wind", "gusts"); n != 4 || len(got) != 2 {
+		t.Errorf("second publish called %d handlers, %d healthy", n, len(got))
+	}
+}
+
+func TestBusUnsubscribeDuringDelivery(t *testing.T) {
+	tests := []struct {
+		name        string
+		unsubscribe func(subs []*Subscription, self int) // Called from handler 1
+		want        []int                                // Handlers called by the first publish
+		wantSecond  []int
+	}{
+		{"itself", func(subs []*Subscription, self int) { subs[self].Unsubscribe() }, []int{0, 1, 2}, []int{0, 2}},
+		{"a later handler", func(subs []*Subscription, _ int) { subs[2].Unsubscribe() }, []int{0, 1}, []int{0, 1}},
+		{"an earlier handler", func(subs []*Subscription, _ int) { subs[0].Unsubscribe() }, []int{0, 1, 2}, []int{1, 2}},
+		{"everyone, twice", func(subs []*Subscription, _ int) {
+			for range 2 {
+				for _, s := range subs {
+					s.Unsubscribe()
+				}
+			}
+		}, []int{0, 1}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := NewBus[int]()
+			var called []int
+			subs := make([]*Subscription, 3)
+			for i := range subs {
+				sub, err := bus.Subscribe("tick", func(string, int) {
+					called = append(called, i)
+					if i == 1 {
+						tt.unsubscribe(subs, i)
+					}
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				subs[i] = sub
+			}
+			n, _ := bus.Publish("tick", 1)
+			if !slices.Equal(called, tt.want) || n != len(tt.want) {
+				t.Errorf("first publish called %v (count %d), want %v", called, n, tt.want)
+			}
+			called = nil
+			bus.Publish("tick", 2)
+			if !slices.Equal(called, tt.wantSecond) {
+				t.Errorf("second publish called %v, want %v", called, tt.wantSecond)
+			}
+			if bus.Len() != len(tt.wantSecond) {
+				t.Errorf("Len = %d, want %d", bus.Len(), len(tt.wantSecond))
+			}
+		})
+	}
+}
+
+func TestBusSubscribeDuringDelivery(t *testing.T) {
+	bus := NewBus[int]()
+	late := 0
+	bus.Subscribe("tick", func(string, int) {
+		bus.Subscribe("tick", func(string, int) { late++ })
+	})
+	if n, _ := bus.Publish("tick", 1); n != 1 || late != 0 {
+		t.Errorf("first publish called %d handlers, late handler %d times; want 1, 0", n, late)
+	}
+	if n, _ := bus.Publish("tick", 2); n != 2 || late != 1 {
+		t.Errorf("second publish called %d handlers, late handler %d times; want 2, 1", n, late)
+	}
+}
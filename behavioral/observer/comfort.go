@@ -0,0 +1,80 @@
+package observer
+
+import (
+	"fmt"
+	"math"
+	"sync"
+)
+
+// --- Heat index and dew point ---
+
+// ComfortDisplay derives the heat index and dew point from temperature (°F) and
+// relative humidity (%). Unlike CurrentConditionsDisplay it has no unit preference:
+// its results are in °F, and Measurement.In converts them.
+type ComfortDisplay struct {
+	name string
+
+	mu        sync.Mutex
+	heatIndex float64
+	dewPoint  float64
+}
+
+func NewComfortDisplay(name string) *ComfortDisplay {
+	return &ComfortDisplay{name: name}
+}
+
+func (c *ComfortDisplay) GetName() string {
+	return c.name
+}
+
+func (c *ComfortDisplay) Update(temperature, humidity, pressure float64) {
+	c.mu.Lock()
+	c.heatIndex = HeatIndex(temperature, humidity)
+	c.dewPoint = DewPoint(temperature, humidity)
+	c.mu.Unlock()
+	fmt.Printf("[%s] Feels like %.1fF, dew point %.1fF\n", c.name, c.HeatIndex(), c.DewPoint())
+}
+
+// HeatIndex returns the last computed heat index in °F.
+func (c *ComfortDisplay) HeatIndex() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.heatIndex
+}
+
+// DewPoint returns the last computed dew point in °F.
+func (c *ComfortDisplay) DewPoint() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.dewPoint
+}
+
+// HeatIndex computes the apparent temperature in °F using the US National Weather
+// Service method: Steadman's simple formula, or the Rothfusz regression (with its
+// humidity adjustments) when that gives 80°F or more.
+func HeatIndex(fahrenheit, humidity float64) float64 {
+	t, rh := fahrenheit, humidity
+	simple := 0.5 * (t + 61 + (t-68)*1.2 + rh*0.094)
+	if (simple+t)/2 < 80 {
+		return simple
+	}
+	hi := -42.379 + 2.04901523*t + 10.14333127*rh - 0.22475541*t*rh -
+		0.00683783*t*t - 0.05481717*rh*rh + 0.00122874*t*t*rh +
+		0.00085282*t*rh*rh - 0.00000199*t*t*rh*rh
+	switch {
+	case rh < 13 && t >= 80 && t <= 112:
+		hi -= (13 - rh) / 4 * math.Sqrt((17-math.Abs(t-95))/17)
+	case rh > 85 && t >= 80 && t <= 87:
+		hi += (rh - 85) / 10 * (87 - t) / 5
+	}
+	return hi
+}
+
+// DewPoint computes the dew point in °F with the Magnus formula.
+func DewPoint(fahrenheit, humidity float64) float64 {
+	const b, c = 17.62, 243.12
+	celsius := (fahrenheit - 32) * 5 / 9
+	gamma := math.Log(max(humidity, 0.01)/100) + b*celsius/(c+celsius)
+	dew := c * gamma / (b - gamma)
+	return dew*9/5 + 32
+}
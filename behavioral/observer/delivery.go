@@ -0,0 +1,273 @@
+package observer
+
+import (
+	"sync"
+	"time"
+)
+
+// --- Asynchronous delivery ---
+// Every registered display gets its own queue and goroutine, so a slow display
+// never holds up the station or the other displays, and Update may safely call
+// back into the station (for example to deregister itself).
+
+// OverflowPolicy decides what happens when a display's queue is full.
+type OverflowPolicy int
+
+const (
+	Block          OverflowPolicy = iota // Wait for room: the station slows down to the display's pace
+	DropOldest                           // Discard the oldest pending reading
+	DropNewest                           // Discard the reading being delivered
+	CoalesceLatest                       // Keep only the latest pending reading; QueueSize is ignored
+)
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case Block:
+		return "block"
+	case DropOldest:
+		return "drop-oldest"
+	case DropNewest:
+		return "drop-newest"
+	case CoalesceLatest:
+		return "coalesce-latest"
+	default:
+		return "unknown"
+	}
+}
+
+// DefaultQueueSize is used when DeliveryOptions.QueueSize is not positive.
+const DefaultQueueSize = 16
+
+// DeliveryOptions configures how readings are queued for one display.
+type DeliveryOptions struct {
+	QueueSize int
+	Overflow  OverflowPolicy
+	// Filters must all allow a reading for it to be queued (see filter.go).
+	Filters []Filter
+	// Debounce holds back readings until none has arrived for this long,
+	// then delivers only the latest one.
+	Debounce time.Duration
+	// Clock times Throttle and Debounce. Nil means the system clock.
+	Clock Clock
+}
+
+// Clock is the time source of a display's filters and debouncing, so tests can
+// step through time instead of sleeping.
+type Clock interface {
+	Now() time.Time
+	// AfterFunc calls f in its own goroutine once d has elapsed, unless stopped first.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a pending AfterFunc call.
+type Timer interface {
+	Stop() bool
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer { return time.AfterFunc(d, f) }
+
+// Reading is one set of measurements delivered to the displays.
+type Reading struct {
+	Temperature float64
+	Humidity    float64
+	Pressure    float64
+}
+
+// TimedDisplay is implemented by displays that need to know when each reading was
+// published, such as a Recorder or a StatisticsDisplay. The station calls UpdateAt instead of Update for them.
+type TimedDisplay interface {
+	WeatherDisplay
+	UpdateAt(at time.Time, r Reading)
+}
+
+// stampedReading is a queued reading with the time the station published it.
+type stampedReading struct {
+	Reading
+	at time.Time
+}
+
+// subscription owns the queue and delivery goroutine of one display.
+type subscription struct {
+	display WeatherDisplay
+	opts    DeliveryOptions
+
+	mu       sync.Mutex
+	cond     *sync.Cond // Broadcast whenever the queue, inFlight or closed change
+	queue    []stampedReading
+	inFlight bool
+	closed   bool
+	dropped  int
+	done     chan struct{} // Closed when the delivery goroutine exits
+
+	last      Reading // Last reading queued, for filters
+	hasLast   bool
+	lastAt    time.Time
+	pending   stampedReading // Reading held back by Debounce
+	debounced Timer
+	debounceN uint64 // Incremented per held-back reading, so stale timers do nothing
+}
+
+func newSubscription(display WeatherDisplay, opts DeliveryOptions) *subscription {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = DefaultQueueSize
+	}
+	if opts.Overflow == CoalesceLatest {
+		opts.QueueSize = 1
+	}
+	if opts.Clock == nil {
+		opts.Clock = systemClock{}
+	}
+	s := &subscription{display: display, opts: opts, done: make(chan struct{})}
+	s.cond = sync.NewCond(&s.mu)
+	go s.run()
+	return s
+}
+
+// offer applies the filters and debouncing to a published reading, then queues it.
+func (s *subscription) offer(r stampedReading, previous Reading, hasPrevious bool) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	fc := FilterContext{
+		Reading:        r.Reading,
+		Previous:       previous,
+		HasPrevious:    hasPrevious,
+		LastDelivered:  s.last,
+		HasDelivered:   s.hasLast,
+		SinceDelivered: s.opts.Clock.Now().Sub(s.lastAt),
+	}
+	for _, allow := range s.opts.Filters {
+		if !allow(fc) {
+			s.mu.Unlock()
+			return
+		}
+	}
+	if s.opts.Debounce > 0 {
+		s.pending = r
+		s.debounceN++
+		n := s.debounceN
+		if s.debounced != nil {
+			s.debounced.Stop()
+		}
+		s.debounced = s.opts.Clock.AfterFunc(s.opts.Debounce, func() { s.releasePending(n) })
+		s.mu.Unlock()
+		return
+	}
+	s.mu.Unlock()
+	s.enqueue(r)
+}
+
+// releasePending queues the reading held back by Debounce if it is still reading n
+// (n == 0 releases whatever is pending).
+func (s *subscription) releasePending(n uint64) {
+	s.mu.Lock()
+	if s.debounced == nil || (n != 0 && n != s.debounceN) {
+		s.mu.Unlock()
+		return
+	}
+	s.debounced.Stop()
+	s.debounced = nil
+	r := s.pending
+	s.mu.Unlock()
+	s.enqueue(r)
+}
+
+func (s *subscription) enqueue(r stampedReading) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.opts.Overflow == Block {
+		for len(s.queue) >= s.opts.QueueSize && !s.closed {
+			s.cond.Wait()
+		}
+	}
+	if s.closed {
+		return
+	}
+	if len(s.queue) >= s.opts.QueueSize {
+		s.dropped++
+		switch s.opts.Overflow {
+		case DropNewest:
+			return
+		case CoalesceLatest:
+			s.queue[len(s.queue)-1] = r
+			s.markQueuedLocked(r)
+			return
+		default: // DropOldest
+			s.queue = s.queue[1:]
+		}
+	}
+	s.queue = append(s.queue, r)
+	s.markQueuedLocked(r)
+	s.cond.Broadcast()
+}
+
+func (s *subscription) markQueuedLocked(r stampedReading) {
+	s.last, s.hasLast, s.lastAt = r.Reading, true, s.opts.Clock.Now()
+}
+
+func (s *subscription) run() {
+	defer close(s.done)
+	for {
+		s.mu.Lock()
+		for len(s.queue) == 0 && !s.closed {
+			s.cond.Wait()
+		}
+		if s.closed {
+			s.mu.Unlock()
+			return
+		}
+		r := s.queue[0]
+		s.queue = s.queue[1:]
+		s.inFlight = true
+		s.cond.Broadcast() // Room for a blocked sender
+		s.mu.Unlock()
+
+		if timed, ok := s.display.(TimedDisplay); ok {
+			timed.UpdateAt(r.at, r.Reading)
+		} else {
+			s.display.Update(r.Temperature, r.Humidity, r.Pressure)
+		}
+
+		s.mu.Lock()
+		s.inFlight = false
+		s.cond.Broadcast()
+		s.mu.Unlock()
+	}
+}
+
+// flush releases a debounced reading right away, then waits until the queue is
+// empty and no Update is running.
+func (s *subscription) flush() {
+	s.releasePending(0)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for (len(s.queue) > 0 || s.inFlight) && !s.closed {
+		s.cond.Wait()
+	}
+}
+
+// close discards pending readings and stops the goroutine once the current
+// Update (if any) returns. It does not wait, so it is safe to call from Update.
+func (s *subscription) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	s.queue = nil
+	if s.debounced != nil {
+		s.debounced.Stop()
+		s.debounced = nil
+	}
+	s.cond.Broadcast()
+}
+
+func (s *subscription) droppedCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
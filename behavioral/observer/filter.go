@@ -0,0 +1,118 @@
+package observer
+
+import (
+	"math"
+	"time"
+)
+
+// --- Subscription filters ---
+// Filters are evaluated by the station before a reading is queued for a display,
+// so an alerting display only wakes up for the readings it cares about.
+
+// Metric selects one of the measured quantities of a Reading.
+type Metric int
+
+const (
+	Temperature Metric = iota
+	Humidity
+	Pressure
+)
+
+func (m Metric) String() string {
+	switch m {
+	case Temperature:
+		return "temperature"
+	case Humidity:
+		return "humidity"
+	case Pressure:
+		return "pressure"
+	default:
+		return "unknown"
+	}
+}
+
+// Of returns the metric's value in r.
+func (m Metric) Of(r Reading) float64 {
+	switch m {
+	case Humidity:
+		return r.Humidity
+	case Pressure:
+		return r.Pressure
+	default:
+		return r.Temperature
+	}
+}
+
+// FilterContext is what a filter decides on.
+type FilterContext struct {
+	Reading        Reading       // The reading being published
+	Previous       Reading       // The reading the station published before it
+	HasPrevious    bool          // False for the station's first reading
+	LastDelivered  Reading       // The last reading queued for this display
+	HasDelivered   bool          // False until a reading has been queued for this display
+	SinceDelivered time.Duration // Time since LastDelivered was queued
+}
+
+// Filter reports whether a reading should be delivered. A display registered with
+// several filters receives a reading only if all of them allow it.
+type Filter func(FilterContext) bool
+
+// OnChange delivers a reading only if it differs from the last one delivered.
+func OnChange() Filter {
+	return func(fc FilterContext) bool {
+		return !fc.HasDelivered || fc.Reading != fc.LastDelivered
+	}
+}
+
+// ChangedBy delivers a reading once the metric has moved by more than delta
+// since the last delivered reading. The first reading is always delivered.
+func ChangedBy(metric Metric, delta float64) Filter {
+	return func(fc FilterContext) bool {
+		return !fc.HasDelivered || math.Abs(metric.Of(fc.Reading)-metric.Of(fc.LastDelivered)) > delta
+	}
+}
+
+// Below delivers a reading when the metric drops below threshold, that is when the
+// previous reading was at or above it (or there was none). It does not repeat while
+// the metric stays below.
+func Below(metric Metric, threshold float64) Filter {
+	return func(fc FilterContext) bool {
+		return metric.Of(fc.Reading) < threshold &&
+			(!fc.HasPrevious || metric.Of(fc.Previous) >= threshold)
+	}
+}
+
+// Above delivers a reading when the metric rises above threshold.
+func Above(metric Metric, threshold float64) Filter {
+	return func(fc FilterContext) bool {
+		return metric.Of(fc.Reading) > threshold &&
+			(!fc.HasPrevious || metric.Of(fc.Previous) <= threshold)
+	}
+}
+
+// Throttle delivers at most one reading per window; readings arriving sooner are dropped.
+// The window is measured on the display's DeliveryOptions.Clock.
+func Throttle(window time.Duration) Filter {
+	return func(fc FilterContext) bool {
+		return !fc.HasDelivered || fc.SinceDelivered >= window
+	}
+}
+
+// Where delivers the readings for which keep returns true.
+func Where(keep func(Reading) bool) Filter {
+	return func(fc FilterContext) bool {
+		return keep(fc.Reading)
+	}
+}
+
+// AnyOf delivers a reading if at least one of filters allows it.
+func AnyOf(filters ...Filter) Filter {
+	return func(fc FilterContext) bool {
+		for _, f := range filters {
+			if f(fc) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,140 @@
+package observer
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock only moves when Advance is called. Due AfterFunc callbacks run inside Advance.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	clock *fakeClock
+	at    time.Time
+	f     func()
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, at: c.now.Add(d), f: f}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	i := slices.Index(t.clock.timers, t)
+	if i < 0 {
+		return false
+	}
+	t.clock.timers = slices.Delete(t.clock.timers, i, i+1)
+	return true
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	var due []*fakeTimer
+	c.timers = slices.DeleteFunc(c.timers, func(t *fakeTimer) bool {
+		if !t.at.After(c.now) {
+			due = append(due, t)
+			return true
+		}
+		return false
+	})
+	c.mu.Unlock()
+	for _, t := range due {
+		t.f()
+	}
+}
+
+func TestFilters(t *testing.T) {
+	at := func(temperature float64) Reading {
+		return Reading{Temperature: temperature, Humidity: 50, Pressure: 1013}
+	}
+	tests := []struct {
+		name   string
+		filter Filter
+		fc     FilterContext
+		want   bool
+	}{
+		{"on change, first", OnChange(), FilterContext{Reading: at(20)}, true},
+		{"on change, same", OnChange(), FilterContext{Reading: at(20), LastDelivered: at(20), HasDelivered: true}, false},
+		{"on change, different", OnChange(), FilterContext{Reading: at(21), LastDelivered: at(20), HasDelivered: true}, true},
+		{"changed by, small", ChangedBy(Temperature, 1), FilterContext{Reading: at(20.5), LastDelivered: at(20), HasDelivered: true}, false},
+		{"changed by, large", ChangedBy(Temperature, 1), FilterContext{Reading: at(18.5), LastDelivered: at(20), HasDelivered: true}, true},
+		{"below, crossing", Below(Temperature, 32), FilterContext{Reading: at(31), Previous: at(33), HasPrevious: true}, true},
+		{"below, staying", Below(Temperature, 32), FilterContext{Reading: at(30), Previous: at(31), HasPrevious: true}, false},
+		{"below, first", Below(Temperature, 32), FilterContext{Reading: at(30)}, true},
+		{"above, crossing", Above(Pressure, 1000), FilterContext{Reading: at(20), Previous: Reading{Pressure: 990}, HasPrevious: true}, true},
+		{"above, staying", Above(Pressure, 1000), FilterContext{Reading: at(20), Previous: at(20), HasPrevious: true}, false},
+		{"throttle, first", Throttle(time.Minute), FilterContext{Reading: at(20)}, true},
+		{"throttle, too soon", Throttle(time.Minute), FilterContext{HasDelivered: true, SinceDelivered: 59 * time.Second}, false},
+		{"throttle, window passed", Throttle(time.Minute), FilterContext{HasDelivered: true, SinceDelivered: time.Minute}, true},
+		{"where", Where(func(r Reading) bool { return r.Humidity > 40 }), FilterContext{Reading: at(20)}, true},
+		{"any of, none", AnyOf(Below(Temperature, 0), Above(Temperature, 100)), FilterContext{Reading: at(20)}, false},
+		{"any of, one", AnyOf(Below(Temperature, 0), Above(Temperature, 10)), FilterContext{Reading: at(20)}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter(tt.fc); got != tt.want {
+				t.Errorf("filter = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimedDelivery(t *testing.T) {
+	type step struct {
+		advance     time.Duration // Before publishing
+		temperature float64
+	}
+	tests := []struct {
+		name  string
+		opts  DeliveryOptions
+		steps []step
+		want  []float64
+	}{
+		{"throttle", DeliveryOptions{Filters: []Filter{Throttle(time.Minute)}},
+			[]step{{0, 1}, {30 * time.Second, 2}, {30 * time.Second, 3}, {0, 4}, {2 * time.Minute, 5}},
+			[]float64{1, 3, 5}},
+		{"debounce", DeliveryOptions{Debounce: 10 * time.Second},
+			[]step{{0, 1}, {5 * time.Second, 2}, {5 * time.Second, 3}, {10 * time.Second, 4}, {20 * time.Second, 5}},
+			[]float64{3, 4, 5}}, // 5 is released by the final Flush
+		{"debounce then throttle", DeliveryOptions{Debounce: 10 * time.Second, Filters: []Filter{Throttle(time.Minute)}},
+			[]step{{0, 1}, {15 * time.Second, 2}, {15 * time.Second, 3}, {time.Minute, 4}},
+			[]float64{1, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fakeClock{now: time.Date(2024, time.January, 5, 6, 0, 0, 0, time.UTC)}
+			tt.opts.Clock = clock
+			ws := NewWeatherStation()
+			defer ws.Close()
+			display := &collector{name: "display"}
+			ws.RegisterObserverWith(display, tt.opts)
+			for _, s := range tt.steps {
+				clock.Advance(s.advance)
+				ws.SetMeasurements(s.temperature, 50, 1013)
+			}
+			ws.Flush()
+			if got := display.temperatures(); !slices.Equal(got, tt.want) {
+				t.Errorf("delivered %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
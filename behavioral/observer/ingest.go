@@ -0,0 +1,399 @@
+package observer
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// --- Ingestion sources ---
+// A Source reads measurements from outside (report files, sensor logs, the network),
+// validates them and pushes them into a WeatherStation. Rejected records are counted
+// rather than stopping the source, and Health reports how each source is doing.
+
+// Source feeds a WeatherStation.
+type Source interface {
+	Name() string
+	// Run pushes readings into station until the input ends (returning nil), ctx is
+	// cancelled (returning ctx.Err()) or the input fails.
+	Run(ctx context.Context, station *WeatherStation) error
+	Health() SourceHealth
+}
+
+// HealthStatus summarises a source's state.
+type HealthStatus string
+
+const (
+	HealthStarting HealthStatus = "starting" // Running, nothing accepted yet
+	HealthOK       HealthStatus = "ok"
+	HealthDegraded HealthStatus = "degraded" // The most recent record was rejected
+	HealthStale    HealthStatus = "stale"    // Nothing accepted within StaleAfter
+	HealthStopped  HealthStatus = "stopped"  // Input ended or the source was cancelled
+	HealthDown     HealthStatus = "down"     // Stopped by an error
+)
+
+// SourceHealth is a snapshot of a source's state and counters.
+type SourceHealth struct {
+	Source      string
+	Status      HealthStatus
+	Accepted    int
+	Rejected    int
+	LastReading time.Time // When the last reading was accepted
+	LastError   error     // Most recent rejection or failure
+}
+
+// SourceOptions configures the health reporting shared by all sources.
+type SourceOptions struct {
+	// StaleAfter marks a running source stale if it accepted nothing for this long.
+	// Zero disables the check.
+	StaleAfter time.Duration
+}
+
+// Plausible ranges for surface observations, in StationUnits. They are a little wider
+// than the world records, so only sensor faults and unit mix-ups are rejected.
+const (
+	MinTemperature = -135.0 // °F
+	MaxTemperature = 140.0
+	MinPressure    = 850.0 // hPa
+	MaxPressure    = 1090.0
+)
+
+// ValidateReading rejects readings (in StationUnits) outside the plausible ranges.
+func ValidateReading(r Reading) error {
+	switch {
+	case math.IsNaN(r.Temperature) || r.Temperature < MinTemperature || r.Temperature > MaxTemperature:
+		return fmt.Errorf("temperature %.1f°F out of range [%g, %g]", r.Temperature, MinTemperature, MaxTemperature)
+	case math.IsNaN(r.Humidity) || r.Humidity < 0 || r.Humidity > 100:
+		return fmt.Errorf("humidity %.1f%% out of range [0, 100]", r.Humidity)
+	case math.IsNaN(r.Pressure) || r.Pressure < MinPressure || r.Pressure > MaxPressure:
+		return fmt.Errorf("pressure %.1f hPa out of range [%g, %g]", r.Pressure, MinPressure, MaxPressure)
+	}
+	return nil
+}
+
+// sourceHealth tracks the counters behind SourceHealth.
+type sourceHealth struct {
+	name string
+	opts SourceOptions
+
+	mu           sync.Mutex
+	accepted     int
+	rejected     int
+	lastReading  time.Time
+	lastErr      error
+	lastRejected bool
+	stopped      bool
+	failed       bool
+}
+
+// publish validates a parsed reading (or records parseErr) and pushes it into station.
+// where locates the record in rejection errors, for example "line 12".
+func (h *sourceHealth) publish(station *WeatherStation, where string, r Reading, parseErr error) {
+	err := parseErr
+	if err == nil {
+		err = ValidateReading(r)
+	}
+	h.mu.Lock()
+	if err != nil {
+		err = fmt.Errorf("%s: %w", where, err)
+		h.rejected++
+		h.lastErr, h.lastRejected = err, true
+		h.mu.Unlock()
+		return
+	}
+	h.accepted++
+	h.lastReading, h.lastRejected = time.Now(), false
+	h.mu.Unlock()
+	station.SetMeasurements(r.Temperature, r.Humidity, r.Pressure)
+}
+
+// stop records that the source ended; err is nil for a clean end or cancellation.
+func (h *sourceHealth) stop(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.stopped = true
+	if err != nil {
+		h.failed, h.lastErr = true, err
+	}
+}
+
+func (h *sourceHealth) Health() SourceHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	health := SourceHealth{
+		Source:      h.name,
+		Accepted:    h.accepted,
+		Rejected:    h.rejected,
+		LastReading: h.lastReading,
+		LastError:   h.lastErr,
+	}
+	switch {
+	case h.failed:
+		health.Status = HealthDown
+	case h.stopped:
+		health.Status = HealthStopped
+	case h.lastRejected:
+		health.Status = HealthDegraded
+	case h.accepted == 0:
+		health.Status = HealthStarting
+	case h.opts.StaleAfter > 0 && time.Since(h.lastReading) > h.opts.StaleAfter:
+		health.Status = HealthStale
+	default:
+		health.Status = HealthOK
+	}
+	return health
+}
+
+// runLines feeds every line of r to parse, stopping at the end of input or on cancellation.
+func runLines(ctx context.Context, r io.Reader, h *sourceHealth, station *WeatherStation, parse func(line string) (Reading, error)) error {
+	lines := newLineReader(r)
+	for {
+		if err := ctx.Err(); err != nil {
+			h.stop(nil)
+			return err
+		}
+		line, n, err := lines.next()
+		if errors.Is(err, io.EOF) {
+			h.stop(nil)
+			return nil
+		}
+		if err != nil {
+			h.stop(err)
+			return err
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		reading, err := parse(line)
+		h.publish(station, fmt.Sprintf("line %d", n), reading, err)
+	}
+}
+
+// lineReader numbers the lines of a text input.
+type lineReader struct {
+	scanner *bufio.Scanner
+	n       int
+}
+
+func newLineReader(r io.Reader) *lineReader {
+	return &lineReader{scanner: bufio.NewScanner(r)}
+}
+
+// next returns the next line and its 1-based number, or io.EOF.
+func (lr *lineReader) next() (string, int, error) {
+	if !lr.scanner.Scan() {
+		if err := lr.scanner.Err(); err != nil {
+			return "", lr.n, err
+		}
+		return "", lr.n, io.EOF
+	}
+	lr.n++
+	return lr.scanner.Text(), lr.n, nil
+}
+
+// --- CSV sensor logs ---
+
+// CSVSource reads a sensor log with a header row naming temperature, humidity and
+// pressure columns once each (in any order, other columns such as a timestamp are ignored).
+// The values are in the units given to NewCSVSource; metrics left at UnitDefault are
+// read in StationUnits.
+type CSVSource struct {
+	*sourceHealth
+	r     io.Reader
+	units Units
+}
+
+func NewCSVSource(name string, r io.Reader, units Units, opts SourceOptions) *CSVSource {
+	return &CSVSource{sourceHealth: &sourceHealth{name: name, opts: opts}, r: r, units: units}
+}
+
+func (s *CSVSource) Name() string {
+	return s.name
+}
+
+func (s *CSVSource) Run(ctx context.Context, station *WeatherStation) error {
+	cr := csv.NewReader(s.r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		err = fmt.Errorf("read CSV header: %w", err)
+		s.stop(err)
+		return err
+	}
+	columns := make(map[Metric]int)
+	for i, name := range header {
+		for _, m := range []Metric{Temperature, Humidity, Pressure} {
+			if !strings.EqualFold(strings.TrimSpace(name), m.String()) {
+				continue
+			}
+			if prev, dup := columns[m]; dup {
+				err := fmt.Errorf("CSV header repeats column %q (columns %d and %d)", strings.TrimSpace(name), prev+1, i+1)
+				s.stop(err)
+				return err
+			}
+			columns[m] = i
+		}
+	}
+	if len(columns) != 3 {
+		err := errors.New("CSV header must name temperature, humidity and pressure columns")
+		s.stop(err)
+		return err
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			s.stop(nil)
+			return err
+		}
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			s.stop(nil)
+			return nil
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			s.publish(station, fmt.Sprintf("line %d", parseErr.StartLine), Reading{}, parseErr.Err)
+			continue
+		}
+		if err != nil {
+			s.stop(err)
+			return err
+		}
+		line, _ := cr.FieldPos(0)
+		reading, err := s.parseRecord(record, columns)
+		s.publish(station, fmt.Sprintf("line %d", line), reading, err)
+	}
+}
+
+func (s *CSVSource) parseRecord(record []string, columns map[Metric]int) (Reading, error) {
+	var values [3]Measurement
+	for i, m := range []Metric{Temperature, Humidity, Pressure} {
+		col := columns[m]
+		if col >= len(record) {
+			return Reading{}, fmt.Errorf("missing %s", m)
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(record[col]), 64)
+		if err != nil {
+			return Reading{}, fmt.Errorf("invalid %s %q", m, record[col])
+		}
+		values[i] = Measurement{Value: v, Unit: s.units.unitFor(m)}
+	}
+	return NewReading(values[0], values[1], values[2])
+}
+
+// --- UDP line protocol ---
+// Each datagram carries one or more lines of key=value fields with unit suffixes:
+//
+//	id=roof-1 t=21.5C h=40% p=1013.2hPa
+//
+// t accepts C, F or K, h accepts % (relative) and p accepts hPa, kPa or inHg.
+// Other keys, such as a sensor id, are ignored.
+
+// UDPSource listens for sensor datagrams.
+type UDPSource struct {
+	*sourceHealth
+	conn net.PacketConn
+}
+
+// ListenUDP opens a UDP socket at addr (for example "127.0.0.1:0" in tests).
+func ListenUDP(name, addr string, opts SourceOptions) (*UDPSource, error) {
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return &UDPSource{sourceHealth: &sourceHealth{name: name, opts: opts}, conn: conn}, nil
+}
+
+func (s *UDPSource) Name() string {
+	return s.name
+}
+
+// Addr is the address the source listens on.
+func (s *UDPSource) Addr() net.Addr {
+	return s.conn.LocalAddr()
+}
+
+// Run reads datagrams until ctx is cancelled, then closes the socket.
+func (s *UDPSource) Run(ctx context.Context, station *WeatherStation) error {
+	stop := context.AfterFunc(ctx, func() { s.conn.Close() })
+	defer stop()
+	buf := make([]byte, 64*1024)
+	for {
+		n, from, err := s.conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				s.stop(nil)
+				return ctx.Err()
+			}
+			s.stop(err)
+			return err
+		}
+		for _, line := range strings.Split(string(buf[:n]), "\n") {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			reading, err := ParseSensorLine(line)
+			s.publish(station, fmt.Sprintf("datagram from %v", from), reading, err)
+		}
+	}
+}
+
+// Close releases the socket of a source that is not running.
+func (s *UDPSource) Close() error {
+	return s.conn.Close()
+}
+
+// ParseSensorLine parses one line of the UDP protocol.
+func ParseSensorLine(line string) (Reading, error) {
+	var t, h, p *Measurement
+	for _, field := range strings.Fields(line) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return Reading{}, fmt.Errorf("field %q is not key=value", field)
+		}
+		var target **Measurement
+		var units map[string]Unit
+		switch strings.ToLower(key) {
+		case "t":
+			target, units = &t, map[string]Unit{"C": Celsius, "F": Fahrenheit, "K": Kelvin}
+		case "h":
+			target, units = &h, map[string]Unit{"%": RelativeHumidity}
+		case "p":
+			target, units = &p, map[string]Unit{"hPa": Hectopascal, "kPa": Kilopascal, "inHg": InchesOfMercury}
+		default:
+			continue
+		}
+		m, err := parseQuantity(value, units)
+		if err != nil {
+			return Reading{}, fmt.Errorf("%s: %w", key, err)
+		}
+		*target = &m
+	}
+	if t == nil || h == nil || p == nil {
+		return Reading{}, errors.New("line needs t, h and p fields")
+	}
+	return NewReading(*t, *h, *p)
+}
+
+// parseQuantity splits a value such as "1013.2hPa" into number and unit suffix.
+func parseQuantity(s string, units map[string]Unit) (Measurement, error) {
+	for suffix, unit := range units {
+		if number, ok := strings.CutSuffix(s, suffix); ok {
+			v, err := strconv.ParseFloat(number, 64)
+			if err != nil {
+				return Measurement{}, fmt.Errorf("invalid number %q", number)
+			}
+			return Measurement{Value: v, Unit: unit}, nil
+		}
+	}
+	return Measurement{}, fmt.Errorf("%q has no recognised unit", s)
+}
@@ -0,0 +1,255 @@
+package observer
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func nearReading(got, want Reading) bool {
+	return near(got.Temperature, want.Temperature, 0.05) && near(got.Humidity, want.Humidity, 0.05) &&
+		near(got.Pressure, want.Pressure, 0.05)
+}
+
+func TestParseMETAR(t *testing.T) {
+	tests := []struct {
+		name    string
+		report  string
+		want    Reading
+		wantErr string
+	}{
+		{"inHg altimeter", "METAR KJFK 121851Z 18012KT 10SM FEW250 28/17 A3002 RMK AO2 SLP165", Reading{82.4, 51.26, 1016.59}, ""},
+		{"hPa altimeter, negatives", "METAR EGLL 120950Z 24008KT 9999 M05/M08 Q1013", Reading{23, 79.49, 1013}, ""},
+		{"saturated", "SPECI LFPG 120930Z 00000KT 0100 FG 10/10 Q1020", Reading{50, 100, 1020}, ""},
+		{"remarks are ignored", "METAR KJFK 121851Z 28/17 A2992 RMK 99/99 A9999", Reading{82.4, 51.26, 1013.21}, ""},
+		{"no temperature", "METAR KJFK 121851Z 18012KT A3002", Reading{}, "no temperature"},
+		{"no altimeter", "METAR KJFK 121851Z 18012KT 28/17", Reading{}, "no altimeter"},
+		{"garbage", "hello world", Reading{}, "no temperature"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMETAR(tt.report)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !nearReading(got, tt.want) {
+				t.Errorf("reading = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSensorLine(t *testing.T) {
+	tests := []struct {
+		line    string
+		want    Reading
+		wantErr bool
+	}{
+		{"id=roof-1 t=21.5C h=40% p=1013.2hPa", Reading{70.7, 40, 1013.2}, false},
+		{"p=29.92inHg T=70F h=55%", Reading{70, 55, 1013.21}, false},
+		{"t=294.15K h=50% p=101.3kPa", Reading{69.8, 50, 1013}, false},
+		{"t=21.5 h=40% p=1013hPa", Reading{}, true},  // No unit
+		{"t=21.5C h=40%", Reading{}, true},           // No pressure
+		{"t=warmC h=40% p=1013hPa", Reading{}, true}, // Not a number
+		{"t=21.5C h=40% p=20C", Reading{}, true},     // Wrong kind of unit
+		{"t=21.5C humid p=1013hPa", Reading{}, true}, // Not key=value
+	}
+	for _, tt := range tests {
+		got, err := ParseSensorLine(tt.line)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: error = %v, want error %t", tt.line, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !nearReading(got, tt.want) {
+			t.Errorf("%q = %+v, want %+v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestValidateReading(t *testing.T) {
+	tests := []struct {
+		reading Reading
+		wantErr string
+	}{
+		{Reading{70, 50, 1013}, ""},
+		{Reading{MaxTemperature, 0, MinPressure}, ""},
+		{Reading{150, 50, 1013}, "temperature"},
+		{Reading{70, -1, 1013}, "humidity"},
+		{Reading{70, 50, 30.1}, "pressure"}, // inHg passed as hPa
+	}
+	for _, tt := range tests {
+		err := ValidateReading(tt.reading)
+		if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("ValidateReading(%+v) = %v, want %q", tt.reading, err, tt.wantErr)
+		}
+	}
+}
+
+func TestTextSources(t *testing.T) {
+	tests := []struct {
+		name         string
+		source       Source
+		want         []float64 // Temperatures published
+		wantRejected int
+		wantStatus   HealthStatus
+		wantErr      string // Substring of Run's error and LastError; empty for none
+	}{
+		{"csv in metric units",
+			NewCSVSource("csv", strings.NewReader("time,Temperature,humidity,pressure\n"+
+				"08:00,20,50,1013\n08:10,25,45,1012\n"), MetricUnits, SourceOptions{}),
+			[]float64{68, 77}, 0, HealthStopped, ""},
+		{"csv with partial units",
+			NewCSVSource("csv", strings.NewReader("pressure,humidity,temperature\n30.0,50,70\n"),
+				Units{Pressure: InchesOfMercury}, SourceOptions{}),
+			[]float64{70}, 0, HealthStopped, ""},
+		{"csv rejects bad rows and keeps going",
+			NewCSVSource("csv", strings.NewReader("temperature,humidity,pressure\n20,50,1013\n"+
+				"90,50,1013\n20,abc,1013\n20,50\n\"unterminated,50,1013\n"), MetricUnits, SourceOptions{}),
+			[]float64{68}, 4, HealthStopped, ""},
+		{"csv without the columns",
+			NewCSVSource("csv", strings.NewReader("temp,hum,press\n20,50,1013\n"), MetricUnits, SourceOptions{}),
+			nil, 0, HealthDown, "must name temperature"},
+		{"csv with a repeated column",
+			NewCSVSource("csv", strings.NewReader("temperature,humidity,pressure,Temperature \n20,50,1013,25\n"), MetricUnits, SourceOptions{}),
+			nil, 0, HealthDown, `repeats column "Temperature" (columns 1 and 4)`},
+		{"csv with repeated ignored columns",
+			NewCSVSource("csv", strings.NewReader("note,temperature,humidity,pressure,note\na,20,50,1013,b\n"), MetricUnits, SourceOptions{}),
+			[]float64{68}, 0, HealthStopped, ""},
+		{"metar",
+			NewMETARSource("metar", strings.NewReader("METAR KJFK 121851Z 28/17 A3002\n\n"+
+				"METAR KJFK 121951Z A3002\nMETAR EGLL 120950Z M05/M08 Q1013\n"), SourceOptions{}),
+			[]float64{82.4, 23}, 1, HealthStopped, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws := NewWeatherStation()
+			defer ws.Close()
+			display := &collector{name: "display"}
+			ws.RegisterObserver(display)
+			err := tt.source.Run(context.Background(), ws)
+			ws.Flush()
+			if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("Run = %v, want %q", err, tt.wantErr)
+			}
+			got := display.temperatures()
+			if len(got) != len(tt.want) {
+				t.Fatalf("published %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !near(got[i], tt.want[i], 0.01) {
+					t.Errorf("published %v, want %v", got, tt.want)
+				}
+			}
+			health := tt.source.Health()
+			if health.Status != tt.wantStatus || health.Accepted != len(tt.want) || health.Rejected != tt.wantRejected {
+				t.Errorf("health = %+v, want %s with %d accepted and %d rejected",
+					health, tt.wantStatus, len(tt.want), tt.wantRejected)
+			}
+		})
+	}
+}
+
+func TestSourceStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	source := NewMETARSource("metar", strings.NewReader("METAR KJFK 121851Z 28/17 A3002\n"), SourceOptions{})
+	ws := NewWeatherStation()
+	defer ws.Close()
+	if err := source.Run(ctx, ws); !errors.Is(err, context.Canceled) {
+		t.Errorf("Run = %v, want context.Canceled", err)
+	}
+	if health := source.Health(); health.Status != HealthStopped || health.Accepted != 0 {
+		t.Errorf("health = %+v, want stopped with nothing accepted", health)
+	}
+}
+
+func TestUDPSource(t *testing.T) {
+	source, err := ListenUDP("roof", "127.0.0.1:0", SourceOptions{StaleAfter: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ws := NewWeatherStation()
+	defer ws.Close()
+	display := &collector{name: "display"}
+	ws.RegisterObserver(display)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- source.Run(ctx, ws) }()
+
+	if status := source.Health().Status; status != HealthStarting {
+		t.Errorf("status before any datagram = %s, want %s", status, HealthStarting)
+	}
+	conn, err := net.Dial("udp", source.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	steps := []struct {
+		datagram     string
+		wantAccepted int
+		wantRejected int
+		wantStatus   HealthStatus
+	}{
+		{"id=roof-1 t=20C h=50% p=1013hPa", 1, 0, HealthOK},
+		{"id=roof-1 t=21C h=50% p=1013hPa\nid=roof-2 t=22C h=50% p=1013hPa\n", 3, 0, HealthOK},
+		{"id=roof-1 t=90C h=50% p=1013hPa", 3, 1, HealthDegraded}, // Out of range
+		{"id=roof-1 t=23C h=50%", 3, 2, HealthDegraded},           // Incomplete
+		{"id=roof-1 t=23C h=50% p=1013hPa", 4, 2, HealthOK},
+	}
+	for _, step := range steps {
+		if _, err := conn.Write([]byte(step.datagram)); err != nil {
+			t.Fatal(err)
+		}
+		health := waitForHealth(t, source, func(h SourceHealth) bool {
+			return h.Accepted+h.Rejected == step.wantAccepted+step.wantRejected
+		})
+		if health.Accepted != step.wantAccepted || health.Rejected != step.wantRejected || health.Status != step.wantStatus {
+			t.Errorf("after %q: health = %+v, want %s with %d accepted and %d rejected",
+				step.datagram, health, step.wantStatus, step.wantAccepted, step.wantRejected)
+		}
+	}
+	if health := source.Health(); health.LastError == nil || !strings.Contains(health.LastError.Error(), "datagram from") {
+		t.Errorf("LastError = %v, want the rejected datagram's sender", health.LastError)
+	}
+	waitForHealth(t, source, func(h SourceHealth) bool { return h.Status == HealthStale })
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run = %v, want context.Canceled", err)
+	}
+	if status := source.Health().Status; status != HealthStopped {
+		t.Errorf("status after cancel = %s, want %s", status, HealthStopped)
+	}
+	ws.Flush()
+	temperatures := display.temperatures()
+	if len(temperatures) != 4 || !near(temperatures[3], 73.4, 0.01) {
+		t.Errorf("published %v, want four readings ending at 73.4°F", temperatures)
+	}
+}
+
+// waitForHealth polls source until ok accepts its health, failing after a second.
+func waitForHealth(t *testing.T, source Source, ok func(SourceHealth) bool) SourceHealth {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		health := source.Health()
+		if ok(health) {
+			return health
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("health still %+v after a second", health)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,88 @@
+package observer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// --- METAR reports ---
+// METAR is the standard aviation weather report, for example
+//
+//	METAR KJFK 121851Z 18012KT 10SM FEW250 28/17 A3002 RMK AO2 SLP165
+//
+// The temperature/dew point group (28/17, "M" marks negatives) is in °C and the
+// altimeter group is either A (hundredths of inHg) or Q (whole hPa). Humidity is
+// derived from the dew point.
+
+var (
+	metarTemperature = regexp.MustCompile(`^(M?\d{2})/(M?\d{2})$`)
+	metarAltimeter   = regexp.MustCompile(`^([AQ])(\d{4})$`)
+)
+
+// ParseMETAR extracts a reading from one METAR or SPECI report.
+func ParseMETAR(report string) (Reading, error) {
+	var temperature, dewPoint, pressure *Measurement
+	for _, group := range strings.Fields(report) {
+		if group == "RMK" {
+			break // Remarks follow; they may contain groups that look like the main ones
+		}
+		if m := metarTemperature.FindStringSubmatch(group); m != nil {
+			temperature = &Measurement{Value: metarCelsius(m[1]), Unit: Celsius}
+			dewPoint = &Measurement{Value: metarCelsius(m[2]), Unit: Celsius}
+		}
+		if m := metarAltimeter.FindStringSubmatch(group); m != nil {
+			v, _ := strconv.ParseFloat(m[2], 64)
+			if m[1] == "A" {
+				pressure = &Measurement{Value: v / 100, Unit: InchesOfMercury}
+			} else {
+				pressure = &Measurement{Value: v, Unit: Hectopascal}
+			}
+		}
+	}
+	switch {
+	case temperature == nil:
+		return Reading{}, errors.New("report has no temperature/dew point group")
+	case pressure == nil:
+		return Reading{}, errors.New("report has no altimeter group")
+	}
+	humidity := Measurement{Value: relativeHumidity(temperature.Value, dewPoint.Value), Unit: RelativeHumidity}
+	return NewReading(*temperature, humidity, *pressure)
+}
+
+func metarCelsius(group string) float64 {
+	v, _ := strconv.ParseFloat(strings.TrimPrefix(group, "M"), 64)
+	if strings.HasPrefix(group, "M") {
+		return -v
+	}
+	return v
+}
+
+// relativeHumidity derives relative humidity (%) from temperature and dew point in °C,
+// with the same Magnus constants as DewPoint.
+func relativeHumidity(celsius, dewPoint float64) float64 {
+	const b, c = 17.62, 243.12
+	return min(100, 100*math.Exp(b*dewPoint/(c+dewPoint)-b*celsius/(c+celsius)))
+}
+
+// METARSource reads METAR reports, one per line, from a file or feed.
+type METARSource struct {
+	*sourceHealth
+	r io.Reader
+}
+
+func NewMETARSource(name string, r io.Reader, opts SourceOptions) *METARSource {
+	return &METARSource{sourceHealth: &sourceHealth{name: name, opts: opts}, r: r}
+}
+
+func (s *METARSource) Name() string {
+	return s.name
+}
+
+func (s *METARSource) Run(ctx context.Context, station *WeatherStation) error {
+	return runLines(ctx, s.r, s.sourceHealth, station, ParseMETAR)
+}
@@ -0,0 +1,377 @@
+package observer
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sync" // For thread-safe observer list, important in real-world scenarios
+	"time"
+)
+
+// --- 2. Observer (Interface) ---
+// Defines the update interface for objects that want to be notified.
+type WeatherDisplay interface {
+	Update(temperature, humidity, pressure float64)
+	GetName() string // To identify displays
+}
+
+// --- 1. Subject (Interface) ---
+// Defines the interface for objects that can be observed.
+type WeatherStationSubject interface {
+	RegisterObserver(display WeatherDisplay)
+	DeregisterObserver(display WeatherDisplay)
+	NotifyObservers()
+}
+
+// --- 4. Concrete Observer ---
+// A concrete display unit that implements the WeatherDisplay interface.
+type CurrentConditionsDisplay struct {
+	name        string
+	mu          sync.Mutex // Update runs on the display's delivery goroutine
+	units       Units
+	temperature Measurement
+	humidity    Measurement
+	// pressure float64 // Could store this too
+}
+
+func NewCurrentConditionsDisplay(name string) *CurrentConditionsDisplay {
+	return &CurrentConditionsDisplay{name: name, units: StationUnits}
+}
+
+// SetUnits chooses the units the display shows its values in.
+func (c *CurrentConditionsDisplay) SetUnits(units Units) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.units = units
+}
+
+func (c *CurrentConditionsDisplay) GetName() string {
+	return c.name
+}
+
+func (c *CurrentConditionsDisplay) Update(temperature, humidity, pressure float64) {
+	c.mu.Lock()
+	t, h, _, err := Reading{temperature, humidity, pressure}.In(c.units)
+	if err != nil {
+		c.mu.Unlock()
+		fmt.Printf("[%s] Cannot show conditions: %v\n", c.name, err)
+		return
+	}
+	c.temperature, c.humidity = t, h
+	c.mu.Unlock()
+	fmt.Printf("[%s] Current conditions: %v and %v humidity\n", c.name, t, h)
+}
+
+// Conditions returns the last temperature and humidity shown, in the display's units.
+func (c *CurrentConditionsDisplay) Conditions() (temperature, humidity Measurement) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.temperature, c.humidity
+}
+
+// Trend is the direction of the pressure over the last few readings.
+type Trend int
+
+const (
+	Steady Trend = iota
+	Rising
+	Falling
+)
+
+func (t Trend) String() string {
+	switch t {
+	case Rising:
+		return "rising"
+	case Falling:
+		return "falling"
+	default:
+		return "steady"
+	}
+}
+
+// DefaultTrendReadings is how many readings NewForecastDisplay bases its trend on.
+const DefaultTrendReadings = 3
+
+// steadyThreshold is the pressure change per reading, relative to the mean pressure,
+// below which the trend counts as steady (about 0.5 hPa per reading at sea level).
+// Being relative, it works whatever unit the pressure is in.
+const steadyThreshold = 0.0005
+
+// Another concrete observer.
+// ForecastDisplay forecasts from the pressure trend over its last few readings.
+type ForecastDisplay struct {
+	name     string
+	readings int
+	mu       sync.Mutex
+	unit     Unit      // Pressure unit shown
+	pressure []float64 // Pressures of the last readings in StationUnits, oldest first
+}
+
+func NewForecastDisplay(name string) *ForecastDisplay {
+	return NewTrendForecastDisplay(name, DefaultTrendReadings)
+}
+
+// NewTrendForecastDisplay bases the trend on the last readings readings (at least 2).
+func NewTrendForecastDisplay(name string, readings int) *ForecastDisplay {
+	return &ForecastDisplay{name: name, readings: max(readings, 2), unit: StationUnits.Pressure}
+}
+
+// SetPressureUnit chooses the unit the display shows pressure in.
+func (f *ForecastDisplay) SetPressureUnit(unit Unit) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.unit = unit
+}
+
+func (f *ForecastDisplay) GetName() string {
+	return f.name
+}
+
+func (f *ForecastDisplay) Update(temperature, humidity, pressure float64) {
+	f.mu.Lock()
+	f.pressure = append(f.pressure, pressure)
+	if len(f.pressure) > f.readings {
+		f.pressure = f.pressure[len(f.pressure)-f.readings:]
+	}
+	shown, err := Measurement{Value: pressure, Unit: StationUnits.Pressure}.In(f.unit)
+	f.mu.Unlock()
+	if err != nil {
+		fmt.Printf("[%s] Cannot show pressure: %v\n", f.name, err)
+		return
+	}
+	fmt.Printf("[%s] Forecast: %s (pressure %s at %v)\n", f.name, f.Forecast(), f.Trend(), shown)
+}
+
+// Trend fits a line through the recent pressures and classifies its slope.
+// With fewer than two readings it reports Steady.
+func (f *ForecastDisplay) Trend() Trend {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := float64(len(f.pressure))
+	if n < 2 {
+		return Steady
+	}
+	var sumX, sumY, sumXY, sumXX float64
+	for i, p := range f.pressure {
+		x := float64(i)
+		sumX += x
+		sumY += p
+		sumXY += x * p
+		sumXX += x * x
+	}
+	slope := (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+	mean := sumY / n
+	switch {
+	case mean == 0 || math.Abs(slope/mean) < steadyThreshold:
+		return Steady
+	case slope > 0:
+		return Rising
+	default:
+		return Falling
+	}
+}
+
+// Forecast turns the trend into a short forecast.
+func (f *ForecastDisplay) Forecast() string {
+	switch f.Trend() {
+	case Rising:
+		return "Improving weather on the way!"
+	case Falling:
+		return "Watch out for cooler, rainy weather"
+	default:
+		return "More of the same"
+	}
+}
+
+// --- 3. Concrete Subject ---
+// The actual weather station that observes conditions.
+// Readings are delivered asynchronously through one queue per display (see delivery.go).
+type WeatherStation struct {
+	observers    map[uint64]*subscription // Keyed by registration, so displays may share a name
+	nextID       uint64
+	temperature  float64
+	humidity     float64
+	pressure     float64
+	published    Reading // Last reading sent to the observers, for crossing filters
+	hasPublished bool
+	mu           sync.Mutex // Guards the observers and the measurements
+	// publishMu is held while a reading is offered to the displays, so concurrent
+	// publishers reach every queue in the same order. It is taken before mu.
+	publishMu sync.Mutex
+}
+
+func NewWeatherStation() *WeatherStation {
+	return &WeatherStation{
+		observers: make(map[uint64]*subscription),
+	}
+}
+
+// RegisterObserver registers display with the default delivery options
+// (a queue of DefaultQueueSize readings that blocks the station when full).
+func (ws *WeatherStation) RegisterObserver(display WeatherDisplay) {
+	ws.RegisterObserverWith(display, DeliveryOptions{})
+}
+
+// RegisterObserverWith registers display with its own queue size and overflow policy.
+// Registering the same display again replaces its earlier registration; different
+// displays that happen to share a name are kept apart. The returned handle removes
+// this registration, just like DeregisterObserver.
+//
+// Displays are recognised by ==, so pointer displays are the norm. A display whose
+// value cannot be compared (say a struct holding a slice) is never matched: each
+// registration of it is separate, and only its handle or Close removes it.
+func (ws *WeatherStation) RegisterObserverWith(display WeatherDisplay, opts DeliveryOptions) *Subscription {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ws.removeLocked(display)
+	id := ws.nextID
+	ws.nextID++
+	sub := newSubscription(display, opts)
+	ws.observers[id] = sub
+	fmt.Printf("WeatherStation: Registered %s\n", display.GetName())
+	return &Subscription{cancel: func() {
+		ws.mu.Lock()
+		defer ws.mu.Unlock()
+		if ws.observers[id] == sub {
+			sub.close()
+			delete(ws.observers, id)
+		}
+	}}
+}
+
+// DeregisterObserver stops deliveries to display and discards its pending readings.
+// It is safe to call from inside the display's own Update.
+func (ws *WeatherStation) DeregisterObserver(display WeatherDisplay) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ws.removeLocked(display)
+	fmt.Printf("WeatherStation: Deregistered %s\n", display.GetName())
+}
+
+// removeLocked closes and removes the registration of display, if any.
+// Callers must hold ws.mu.
+func (ws *WeatherStation) removeLocked(display WeatherDisplay) {
+	if id, sub, ok := ws.findLocked(display); ok {
+		sub.close()
+		delete(ws.observers, id)
+	}
+}
+
+// findLocked looks a display up by identity rather than by name. Callers must hold ws.mu.
+func (ws *WeatherStation) findLocked(display WeatherDisplay) (uint64, *subscription, bool) {
+	if !reflect.ValueOf(display).Comparable() {
+		return 0, nil, false
+	}
+	for id, sub := range ws.observers {
+		// The registered display may be of another type that is not comparable.
+		if reflect.ValueOf(sub.display).Comparable() && sub.display == display {
+			return id, sub, true
+		}
+	}
+	return 0, nil, false
+}
+
+// NotifyObservers queues the current measurements for every display whose filters
+// allow them, and returns without waiting for them to be processed (unless a Block
+// queue is full). Concurrent calls are serialized, so every display receives the
+// readings in the order the station published them. An Update that publishes to its
+// own station should not use a Block queue, since it could end up waiting on itself.
+func (ws *WeatherStation) NotifyObservers() {
+	ws.publishMu.Lock()
+	defer ws.publishMu.Unlock()
+	ws.notifyLocked(time.Now())
+}
+
+// notifyLocked publishes the current measurements stamped with at. Callers must hold
+// ws.publishMu.
+func (ws *WeatherStation) notifyLocked(at time.Time) {
+	ws.mu.Lock()
+	reading := Reading{Temperature: ws.temperature, Humidity: ws.humidity, Pressure: ws.pressure}
+	previous, hasPrevious := ws.published, ws.hasPublished
+	ws.published, ws.hasPublished = reading, true
+	ws.mu.Unlock()
+	subs := ws.subscriptions() // No lock is held while queueing
+
+	fmt.Println("WeatherStation: Notifying observers...")
+	stamped := stampedReading{Reading: reading, at: at}
+	for _, sub := range subs {
+		sub.offer(stamped, previous, hasPrevious)
+	}
+}
+
+// Method to simulate state change in the Subject.
+// The values are in StationUnits (°F, % relative humidity, hPa); use SetReading for others.
+// The measurements are published before another call can replace them.
+func (ws *WeatherStation) SetMeasurements(temperature, humidity, pressure float64) {
+	ws.publishAt(time.Now(), Reading{Temperature: temperature, Humidity: humidity, Pressure: pressure})
+}
+
+// publishAt sets and publishes r as if it had been measured at at. Replay uses it so
+// TimedDisplays see the recorded times rather than the time of the replay.
+func (ws *WeatherStation) publishAt(at time.Time, r Reading) {
+	ws.publishMu.Lock()
+	defer ws.publishMu.Unlock()
+	ws.mu.Lock()
+	ws.temperature = r.Temperature
+	ws.humidity = r.Humidity
+	ws.pressure = r.Pressure
+	ws.mu.Unlock()
+	fmt.Println("\nWeatherStation: New measurements received.")
+	ws.notifyLocked(at) // Notify all registered observers
+}
+
+// SetReading converts unit-tagged measurements to StationUnits and publishes them.
+// Nothing is published if a measurement is in the wrong kind of unit or out of range.
+func (ws *WeatherStation) SetReading(temperature, humidity, pressure Measurement) error {
+	r, err := NewReading(temperature, humidity, pressure)
+	if err != nil {
+		return err
+	}
+	ws.SetMeasurements(r.Temperature, r.Humidity, r.Pressure)
+	return nil
+}
+
+// Flush waits until every display has processed its pending readings.
+// It must not be called from inside Update, which would wait for itself.
+func (ws *WeatherStation) Flush() {
+	for _, sub := range ws.subscriptions() {
+		sub.flush()
+	}
+}
+
+// Dropped reports how many readings display lost to its overflow policy.
+func (ws *WeatherStation) Dropped(display WeatherDisplay) int {
+	ws.mu.Lock()
+	_, sub, ok := ws.findLocked(display)
+	ws.mu.Unlock()
+	if !ok {
+		return 0
+	}
+	return sub.droppedCount()
+}
+
+// Close deregisters every display and waits for their delivery goroutines to finish.
+// Like Flush, it must not be called from inside Update.
+func (ws *WeatherStation) Close() {
+	ws.mu.Lock()
+	var subs []*subscription
+	for id, sub := range ws.observers {
+		sub.close()
+		subs = append(subs, sub)
+		delete(ws.observers, id)
+	}
+	ws.mu.Unlock()
+	for _, sub := range subs {
+		<-sub.done
+	}
+}
+
+func (ws *WeatherStation) subscriptions() []*subscription {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	subs := make([]*subscription, 0, len(ws.observers))
+	for _, sub := range ws.observers {
+		subs = append(subs, sub)
+	}
+	return subs
+}
@@ -0,0 +1,175 @@
+package observer
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// collector records the temperatures it is sent.
+type collector struct {
+	name string
+	mu   sync.Mutex
+	got  []float64
+}
+
+func (c *collector) GetName() string { return c.name }
+
+func (c *collector) Update(temperature, humidity, pressure float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.got = append(c.got, temperature)
+}
+
+func (c *collector) temperatures() []float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]float64(nil), c.got...)
+}
+
+// gate is a collector whose Update waits for release once it has signalled started.
+type gate struct {
+	collector
+	started chan struct{}
+	release chan struct{}
+}
+
+func (g *gate) Update(temperature, humidity, pressure float64) {
+	g.collector.Update(temperature, humidity, pressure)
+	select {
+	case g.started <- struct{}{}:
+	default:
+	}
+	<-g.release
+}
+
+func TestBlockedPublisherHoldsLaterReadings(t *testing.T) {
+	// Map order decides which display a publisher reaches first, so try a few stations.
+	for range 10 {
+		ws := NewWeatherStation()
+		slow := &gate{collector: collector{name: "slow"}, started: make(chan struct{}, 1), release: make(chan struct{})}
+		fast := &collector{name: "fast"}
+		ws.RegisterObserverWith(slow, DeliveryOptions{QueueSize: 1, Overflow: Block})
+		ws.RegisterObserver(fast)
+
+		ws.SetMeasurements(1, 50, 1013)
+		<-slow.started                  // Reading 1 is in slow's Update,
+		ws.SetMeasurements(2, 50, 1013) // reading 2 fills its queue,
+		var wg sync.WaitGroup
+		for _, temperature := range []float64{3, 4} { // and these wait for room.
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ws.SetMeasurements(temperature, 50, 1013)
+			}()
+			time.Sleep(10 * time.Millisecond)
+		}
+		close(slow.release)
+		wg.Wait()
+		ws.Flush()
+		ws.Close()
+
+		if got, want := fast.temperatures(), slow.temperatures(); !slices.Equal(got, want) {
+			t.Fatalf("fast display got %v, slow display got %v", got, want)
+		}
+	}
+}
+
+func TestConcurrentPublishersKeepOneOrder(t *testing.T) {
+	const publishers, readings = 8, 100
+	ws := NewWeatherStation()
+	defer ws.Close()
+	displays := make([]*collector, 8)
+	for i := range displays {
+		displays[i] = &collector{name: fmt.Sprintf("display %d", i)}
+		ws.RegisterObserverWith(displays[i], DeliveryOptions{QueueSize: publishers * readings})
+	}
+
+	var wg sync.WaitGroup
+	for p := range publishers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range readings {
+				ws.SetMeasurements(float64(p*1000+i), 50, 1013)
+			}
+		}()
+	}
+	wg.Wait()
+	ws.Flush()
+
+	want := displays[0].temperatures()
+	if len(want) != publishers*readings {
+		t.Fatalf("%s got %d readings, want %d", displays[0].name, len(want), publishers*readings)
+	}
+	for _, d := range displays[1:] {
+		got := d.temperatures()
+		for i := range want {
+			if i >= len(got) || got[i] != want[i] {
+				t.Fatalf("%s diverges from %s at reading %d", d.name, displays[0].name, i)
+			}
+		}
+	}
+}
+
+// valueDisplay is comparable; sliceDisplay and boxDisplay are not, the latter only
+// because of what its interface field holds.
+type valueDisplay struct{ name string }
+
+type sliceDisplay struct{ names []string }
+
+type boxDisplay struct{ state any }
+
+func (valueDisplay) Update(float64, float64, float64) {}
+func (d valueDisplay) GetName() string                { return d.name }
+func (sliceDisplay) Update(float64, float64, float64) {}
+func (sliceDisplay) GetName() string                  { return "slice" }
+func (boxDisplay) Update(float64, float64, float64)   {}
+func (boxDisplay) GetName() string                    { return "box" }
+
+func TestRegistrationIdentity(t *testing.T) {
+	tests := []struct {
+		name            string
+		display         WeatherDisplay
+		afterRegisters  int // Registrations after registering the display twice
+		afterDeregister int // Registrations after DeregisterObserver
+	}{
+		{"pointer", &collector{name: "pointer"}, 1, 0},
+		{"comparable value", valueDisplay{name: "value"}, 1, 0},
+		{"slice field", sliceDisplay{names: []string{"a"}}, 2, 2},
+		{"interface holding a slice", boxDisplay{state: []int{1}}, 2, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws := NewWeatherStation()
+			defer ws.Close()
+			// Registrations of other types must not trip up the lookups either.
+			ws.RegisterObserver(sliceDisplay{})
+			ws.RegisterObserver(boxDisplay{state: map[string]int{}})
+			ws.RegisterObserver(valueDisplay{name: "other"})
+			others := len(ws.observers)
+
+			first := ws.RegisterObserverWith(tt.display, DeliveryOptions{})
+			second := ws.RegisterObserverWith(tt.display, DeliveryOptions{})
+			if n := len(ws.observers) - others; n != tt.afterRegisters {
+				t.Errorf("after registering twice: %d registrations, want %d", n, tt.afterRegisters)
+			}
+			ws.SetMeasurements(70, 50, 1013)
+			ws.Flush()
+			if n := ws.Dropped(tt.display); n != 0 {
+				t.Errorf("Dropped = %d, want 0", n)
+			}
+			ws.DeregisterObserver(tt.display)
+			if n := len(ws.observers) - others; n != tt.afterDeregister {
+				t.Errorf("after DeregisterObserver: %d registrations, want %d", n, tt.afterDeregister)
+			}
+			first.Unsubscribe()
+			second.Unsubscribe()
+			if n := len(ws.observers) - others; n != 0 {
+				t.Errorf("after Unsubscribe: %d registrations, want 0", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package observer
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"os"
+	"sync"
+	"time"
+)
+
+// --- Recording and replay ---
+// A recording is a compact time series of readings:
+//
+//	"WXR1"                                   magic
+//	per sample:
+//	  varint   nanoseconds since the previous sample (since the Unix epoch for the first)
+//	  3 × uvarint  bit-reversed XOR of each value with the previous sample's value
+//
+// Readings change little between samples, so the XOR is mostly zero bits and an
+// unchanged value takes a single byte.
+
+const recordingMagic = "WXR1"
+
+// ErrNotRecording is returned when a file does not start with the recording magic.
+var ErrNotRecording = errors.New("not a weather recording")
+
+// Sample is one recorded reading.
+type Sample struct {
+	At time.Time
+	Reading
+}
+
+// sampleCodec holds the delta state shared by the encoder and decoder.
+type sampleCodec struct {
+	prevAt     int64
+	prevValues [3]uint64
+}
+
+func (c *sampleCodec) encode(buf []byte, s Sample) []byte {
+	at := s.At.UnixNano()
+	buf = binary.AppendVarint(buf, at-c.prevAt)
+	c.prevAt = at
+	for i, v := range [3]float64{s.Temperature, s.Humidity, s.Pressure} {
+		b := math.Float64bits(v)
+		buf = binary.AppendUvarint(buf, bits.Reverse64(b^c.prevValues[i]))
+		c.prevValues[i] = b
+	}
+	return buf
+}
+
+func (c *sampleCodec) decode(r io.ByteReader) (Sample, error) {
+	delta, err := binary.ReadVarint(r)
+	if err != nil {
+		return Sample{}, err // io.EOF here is a clean end of the recording
+	}
+	var values [3]float64
+	for i := range values {
+		x, err := binary.ReadUvarint(r)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return Sample{}, err
+		}
+		c.prevValues[i] ^= bits.Reverse64(x)
+		values[i] = math.Float64frombits(c.prevValues[i])
+	}
+	c.prevAt += delta
+	return Sample{
+		At:      time.Unix(0, c.prevAt),
+		Reading: Reading{Temperature: values[0], Humidity: values[1], Pressure: values[2]},
+	}, nil
+}
+
+// Recorder is an observer that writes every reading it receives, stamped with the time
+// the station published it. Register it with the default Block policy so no reading
+// is dropped. Writes are buffered; call Flush or Close to get them to the writer.
+type Recorder struct {
+	name string
+
+	mu     sync.Mutex
+	w      *bufio.Writer
+	closer io.Closer // Non-nil when the recorder owns the file
+	codec  sampleCodec
+	buf    []byte
+	count  int
+	err    error // First write error; later samples are not written
+}
+
+// NewRecorder records to w. The magic is written immediately.
+func NewRecorder(name string, w io.Writer) *Recorder {
+	r := &Recorder{name: name, w: bufio.NewWriter(w)}
+	_, r.err = r.w.WriteString(recordingMagic)
+	return r
+}
+
+// CreateRecording creates (or truncates) the file at path and records to it.
+func CreateRecording(name, path string) (*Recorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	r := NewRecorder(name, f)
+	r.closer = f
+	return r, nil
+}
+
+func (r *Recorder) GetName() string {
+	return r.name
+}
+
+// Update records a reading stamped with the current time. The station calls UpdateAt
+// instead, with the time the reading was published.
+func (r *Recorder) Update(temperature, humidity, pressure float64) {
+	r.UpdateAt(time.Now(), Reading{Temperature: temperature, Humidity: humidity, Pressure: pressure})
+}
+
+func (r *Recorder) UpdateAt(at time.Time, reading Reading) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	r.buf = r.codec.encode(r.buf[:0], Sample{At: at, Reading: reading})
+	if _, r.err = r.w.Write(r.buf); r.err == nil {
+		r.count++
+	}
+}
+
+// Count reports how many samples have been recorded.
+func (r *Recorder) Count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.count
+}
+
+// Err reports the first write error, after which the recorder stopped recording.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+func (r *Recorder) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err == nil {
+		r.err = r.w.Flush()
+	}
+	return r.err
+}
+
+// Close flushes the recording and closes the file if the recorder created it.
+func (r *Recorder) Close() error {
+	err := r.Flush()
+	if r.closer != nil {
+		err = errors.Join(err, r.closer.Close())
+	}
+	return err
+}
+
+// RecordingReader reads samples back from a recording.
+type RecordingReader struct {
+	r     *bufio.Reader
+	codec sampleCodec
+	read  int
+}
+
+func NewRecordingReader(r io.Reader) (*RecordingReader, error) {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(recordingMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || string(magic) != recordingMagic {
+		return nil, ErrNotRecording
+	}
+	return &RecordingReader{r: br}, nil
+}
+
+// Next returns the next sample, or io.EOF at the end. A recording cut off in the
+// middle of a sample (for example by a crash) ends with io.ErrUnexpectedEOF.
+func (rr *RecordingReader) Next() (Sample, error) {
+	s, err := rr.codec.decode(rr.r)
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			err = fmt.Errorf("sample %d: %w", rr.read+1, err)
+		}
+		return Sample{}, err
+	}
+	rr.read++
+	return s, nil
+}
+
+// ReadRecording reads a whole recording into memory.
+func ReadRecording(r io.Reader) ([]Sample, error) {
+	rr, err := NewRecordingReader(r)
+	if err != nil {
+		return nil, err
+	}
+	var samples []Sample
+	for {
+		s, err := rr.Next()
+		if errors.Is(err, io.EOF) {
+			return samples, nil
+		}
+		if err != nil {
+			return samples, err
+		}
+		samples = append(samples, s)
+	}
+}
+
+// AsFastAsPossible replays without waiting between samples.
+const AsFastAsPossible = 0
+
+// Replay publishes a recording through station. Each reading keeps its recorded time,
+// which TimedDisplays receive through UpdateAt. Speed 1 keeps the recorded gaps between
+// samples, speed 10 replays ten times faster, and AsFastAsPossible does not wait at all.
+// It returns how many samples were replayed.
+func Replay(ctx context.Context, station *WeatherStation, recording io.Reader, speed float64) (int, error) {
+	rr, err := NewRecordingReader(recording)
+	if err != nil {
+		return 0, err
+	}
+	var first time.Time
+	start := time.Now()
+	replayed := 0
+	for {
+		s, err := rr.Next()
+		if errors.Is(err, io.EOF) {
+			return replayed, nil
+		}
+		if err != nil {
+			return replayed, err
+		}
+		if replayed == 0 {
+			first = s.At
+		}
+		if speed > 0 {
+			due := start.Add(time.Duration(float64(s.At.Sub(first)) / speed))
+			if err := sleepUntil(ctx, due); err != nil {
+				return replayed, err
+			}
+		} else if err := ctx.Err(); err != nil {
+			return replayed, err
+		}
+		station.publishAt(s.At, s.Reading)
+		replayed++
+	}
+}
+
+func sleepUntil(ctx context.Context, due time.Time) error {
+	wait := time.Until(due)
+	if wait <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,244 @@
+package observer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+var recordingStart = time.Date(2024, time.January, 5, 6, 0, 0, 123, time.UTC)
+
+// record encodes samples into a recording.
+func record(t *testing.T, samples []Sample) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	r := NewRecorder("recorder", &buf)
+	for _, s := range samples {
+		r.UpdateAt(s.At, s.Reading)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if r.Count() != len(samples) {
+		t.Fatalf("recorded %d samples, want %d", r.Count(), len(samples))
+	}
+	return buf.Bytes()
+}
+
+// sameSample compares the time and the exact bits of every value, so NaN and -0 count.
+func sameSample(a, b Sample) bool {
+	return a.At.Equal(b.At) &&
+		math.Float64bits(a.Temperature) == math.Float64bits(b.Temperature) &&
+		math.Float64bits(a.Humidity) == math.Float64bits(b.Humidity) &&
+		math.Float64bits(a.Pressure) == math.Float64bits(b.Pressure)
+}
+
+// series returns n samples every gap, with slowly rising values.
+func series(n int, gap time.Duration) []Sample {
+	samples := make([]Sample, n)
+	for i := range samples {
+		samples[i] = Sample{At: recordingStart.Add(time.Duration(i) * gap), Reading: Reading{
+			Temperature: 70 + float64(i)/10, Humidity: 50, Pressure: 1013.25,
+		}}
+	}
+	return samples
+}
+
+func TestRecordingRoundTrip(t *testing.T) {
+	at := func(d time.Duration) time.Time { return recordingStart.Add(d) }
+	tests := []struct {
+		name    string
+		samples []Sample
+	}{
+		{"empty", nil},
+		{"one", []Sample{{At: at(0), Reading: Reading{71.5, 40, 1012}}}},
+		{"series", series(50, time.Second)},
+		{"unchanged values", []Sample{{at(0), Reading{70, 50, 1013}}, {at(time.Minute), Reading{70, 50, 1013}}}},
+		{"negative and special values", []Sample{
+			{at(0), Reading{-40, 0, 0}},
+			{at(time.Nanosecond), Reading{math.Copysign(0, -1), math.Inf(1), math.NaN()}},
+			{at(time.Hour), Reading{math.MaxFloat64, math.SmallestNonzeroFloat64, -1e-300}},
+		}},
+		{"time goes backwards", []Sample{{at(time.Hour), Reading{1, 2, 3}}, {at(0), Reading{4, 5, 6}}, {at(2 * time.Hour), Reading{7, 8, 9}}}},
+		{"before the epoch", []Sample{{time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC), Reading{1, 2, 3}}, {at(0), Reading{1, 2, 3}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := record(t, tt.samples)
+			got, err := ReadRecording(bytes.NewReader(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.samples) {
+				t.Fatalf("read %d samples, want %d", len(got), len(tt.samples))
+			}
+			for i := range got {
+				if !sameSample(got[i], tt.samples[i]) {
+					t.Errorf("sample %d = %+v, want %+v", i, got[i], tt.samples[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRecordingIsCompact(t *testing.T) {
+	var c sampleCodec
+	first := c.encode(nil, Sample{At: recordingStart, Reading: Reading{70, 50, 1013}})
+	// An unchanged reading one second later: a 5-byte time delta and one byte per value.
+	same := c.encode(nil, Sample{At: recordingStart.Add(time.Second), Reading: Reading{70, 50, 1013}})
+	if len(same) != 5+3 {
+		t.Errorf("unchanged sample took %d bytes, want 8 (first took %d)", len(same), len(first))
+	}
+	data := record(t, series(1000, time.Second))
+	if perSample := float64(len(data)) / 1000; perSample > 20 {
+		t.Errorf("a slowly changing series takes %.1f bytes per sample, want well under the 32 of fixed-width fields", perSample)
+	}
+}
+
+func TestRecordingRejectsBadInput(t *testing.T) {
+	data := record(t, series(5, time.Second))
+	boundaries := map[int]bool{len(recordingMagic): true}
+	{
+		var c sampleCodec
+		size := len(recordingMagic)
+		for _, s := range series(5, time.Second) {
+			size += len(c.encode(nil, s))
+			boundaries[size] = true
+		}
+	}
+
+	for _, input := range [][]byte{nil, []byte("WX"), []byte("WXR2"), []byte("wxr1\x00\x00\x00\x00")} {
+		if _, err := ReadRecording(bytes.NewReader(input)); !errors.Is(err, ErrNotRecording) {
+			t.Errorf("ReadRecording(%q) = %v, want ErrNotRecording", input, err)
+		}
+	}
+
+	// Cut after every byte: a cut between samples is a shorter recording, a cut inside
+	// one is io.ErrUnexpectedEOF, and every sample before the cut is still returned.
+	for cut := len(recordingMagic); cut < len(data); cut++ {
+		got, err := ReadRecording(bytes.NewReader(data[:cut]))
+		if boundaries[cut] {
+			if err != nil {
+				t.Errorf("cut at sample boundary %d: %v", cut, err)
+			}
+			continue
+		}
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("cut at %d: err = %v, want io.ErrUnexpectedEOF", cut, err)
+		}
+		for i, s := range got {
+			if !sameSample(s, series(5, time.Second)[i]) {
+				t.Errorf("cut at %d: sample %d = %+v", cut, i, s)
+			}
+		}
+	}
+
+	// A varint that never ends overflows instead of being read as a huge value.
+	corrupt := append([]byte(recordingMagic), bytes.Repeat([]byte{0xff}, 12)...)
+	if _, err := ReadRecording(bytes.NewReader(corrupt)); err == nil || errors.Is(err, io.EOF) {
+		t.Errorf("overlong varint: err = %v, want an overflow error", err)
+	}
+	rr, err := NewRecordingReader(bytes.NewReader(corrupt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rr.Next(); err == nil || !strings.HasPrefix(err.Error(), "sample 1:") {
+		t.Errorf("Next = %v, want an error naming sample 1", err)
+	}
+}
+
+// replayInto replays data into a station whose only display is a Recorder, and returns
+// what the Recorder saw, how many samples Replay reported and how long it took.
+func replayInto(t *testing.T, ctx context.Context, data []byte, speed float64) ([]Sample, int, time.Duration, error) {
+	t.Helper()
+	var out bytes.Buffer
+	rec := NewRecorder("replayed", &out)
+	ws := NewWeatherStation()
+	ws.RegisterObserver(rec)
+	start := time.Now()
+	n, err := Replay(ctx, ws, bytes.NewReader(data), speed)
+	elapsed := time.Since(start)
+	ws.Flush()
+	ws.Close()
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+	got, readErr := ReadRecording(&out)
+	if readErr != nil {
+		t.Fatal(readErr)
+	}
+	return got, n, elapsed, err
+}
+
+func TestReplay(t *testing.T) {
+	tests := []struct {
+		name        string
+		samples     []Sample
+		speed       float64
+		minDuration time.Duration
+		maxDuration time.Duration
+	}{
+		{"as fast as possible", series(20, time.Hour), AsFastAsPossible, 0, time.Second},
+		{"real time", series(3, 40*time.Millisecond), 1, 80 * time.Millisecond, time.Second},
+		{"ten times faster", series(3, 200*time.Millisecond), 10, 40 * time.Millisecond, 350 * time.Millisecond},
+		{"empty", nil, 1, 0, time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, n, elapsed, err := replayInto(t, context.Background(), record(t, tt.samples), tt.speed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != len(tt.samples) || len(got) != len(tt.samples) {
+				t.Fatalf("replayed %d, display saw %d, want %d", n, len(got), len(tt.samples))
+			}
+			// The display sees the recorded times, not the time of the replay.
+			for i := range got {
+				if !sameSample(got[i], tt.samples[i]) {
+					t.Errorf("sample %d = %+v, want %+v", i, got[i], tt.samples[i])
+				}
+			}
+			if elapsed < tt.minDuration || elapsed > tt.maxDuration {
+				t.Errorf("took %v, want between %v and %v", elapsed, tt.minDuration, tt.maxDuration)
+			}
+		})
+	}
+}
+
+func TestReplayCancellation(t *testing.T) {
+	data := record(t, series(3, time.Hour))
+	for _, speed := range []float64{1, 60} {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		got, n, elapsed, err := replayInto(t, ctx, data, speed)
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("speed %g: err = %v, want context.DeadlineExceeded", speed, err)
+		}
+		// The first sample is due at once; the second is an hour (or a minute) away.
+		if n != 1 || len(got) != 1 {
+			t.Errorf("speed %g: replayed %d, display saw %d; want 1", speed, n, len(got))
+		}
+		if elapsed > time.Second {
+			t.Errorf("speed %g: cancellation took %v", speed, elapsed)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, n, _, err := replayInto(t, ctx, data, AsFastAsPossible); n != 0 || !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled before starting: replayed %d, err %v; want 0, context.Canceled", n, err)
+	}
+	if _, err := Replay(context.Background(), NewWeatherStation(), bytes.NewReader([]byte("nope")), 1); !errors.Is(err, ErrNotRecording) {
+		t.Errorf("Replay of a non-recording = %v, want ErrNotRecording", err)
+	}
+
+	// A recording cut off mid-sample replays what it can and reports the damage.
+	if _, n, _, err := replayInto(t, context.Background(), data[:len(data)-1], AsFastAsPossible); n != 2 || !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("truncated recording: replayed %d, err %v; want 2, io.ErrUnexpectedEOF", n, err)
+	}
+}
@@ -0,0 +1,303 @@
+package observer
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// --- Server-Sent Events ---
+// SSEBroadcaster is an observer that streams every reading to connected HTTP clients
+// (browsers use EventSource). Register it with a station and mount it on a mux:
+//
+//	GET /weather?metrics=temperature,pressure
+//
+// Each reading is sent as
+//
+//	id: 5f3a9c0e21d4b7a8-42
+//	event: reading
+//	data: {"time":"2024-05-01T12:00:00Z","temperature":80,"pressure":1029.5}
+//
+// with values in StationUnits. An event ID is the broadcaster's run ID, random for every
+// broadcaster, followed by a sequence number that starts at 1. A client that falls
+// behind is disconnected rather than allowed to hold up the others; when it reconnects
+// with Last-Event-ID it is sent the readings it missed from the resume buffer. If they
+// are no longer buffered, or the ID comes from another run (say from before a server
+// restart), it first gets a "gap" event, so it knows to refresh its state.
+//
+// Only SSE is provided. A WebSocket transport is out of scope: the standard library has
+// no WebSocket support, and EventSource already covers one-way streaming to browsers.
+
+// SSEOptions configures an SSEBroadcaster. Zero values pick the defaults.
+type SSEOptions struct {
+	ResumeBuffer int           // Readings kept for Last-Event-ID resumes (default 256)
+	ClientQueue  int           // Readings queued per client before it is dropped (default 16)
+	Heartbeat    time.Duration // Interval of keep-alive comments (default 15s)
+}
+
+type sseEvent struct {
+	id      uint64
+	at      time.Time
+	reading Reading
+}
+
+type sseClient struct {
+	metrics []Metric
+	events  chan sseEvent
+	dropped chan struct{} // Closed when the client fell too far behind
+}
+
+// SSEBroadcaster implements WeatherDisplay (and TimedDisplay) and http.Handler.
+type SSEBroadcaster struct {
+	name string
+	opts SSEOptions
+
+	run string // Prefix of every event ID, so IDs from other runs are recognised
+
+	mu      sync.Mutex
+	nextID  uint64
+	history []sseEvent // Last ResumeBuffer events, oldest first
+	clients map[*sseClient]struct{}
+	closed  chan struct{}
+}
+
+func NewSSEBroadcaster(name string, opts SSEOptions) *SSEBroadcaster {
+	if opts.ResumeBuffer <= 0 {
+		opts.ResumeBuffer = 256
+	}
+	if opts.ClientQueue <= 0 {
+		opts.ClientQueue = 16
+	}
+	if opts.Heartbeat <= 0 {
+		opts.Heartbeat = 15 * time.Second
+	}
+	var run [8]byte
+	rand.Read(run[:])
+	return &SSEBroadcaster{
+		name:    name,
+		opts:    opts,
+		run:     hex.EncodeToString(run[:]),
+		nextID:  1,
+		clients: make(map[*sseClient]struct{}),
+		closed:  make(chan struct{}),
+	}
+}
+
+func (b *SSEBroadcaster) GetName() string {
+	return b.name
+}
+
+func (b *SSEBroadcaster) Update(temperature, humidity, pressure float64) {
+	b.UpdateAt(time.Now(), Reading{Temperature: temperature, Humidity: humidity, Pressure: pressure})
+}
+
+func (b *SSEBroadcaster) UpdateAt(at time.Time, r Reading) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ev := sseEvent{id: b.nextID, at: at, reading: r}
+	b.nextID++
+	b.history = append(b.history, ev)
+	if len(b.history) > b.opts.ResumeBuffer {
+		b.history = b.history[len(b.history)-b.opts.ResumeBuffer:]
+	}
+	for c := range b.clients {
+		select {
+		case c.events <- ev:
+		default: // Too slow: drop the client, it can resume from the buffer
+			close(c.dropped)
+			delete(b.clients, c)
+		}
+	}
+}
+
+// Clients reports how many clients are connected.
+func (b *SSEBroadcaster) Clients() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.clients)
+}
+
+// Close ends every stream. The broadcaster keeps buffering readings but refuses new clients.
+func (b *SSEBroadcaster) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	select {
+	case <-b.closed:
+	default:
+		close(b.closed)
+	}
+}
+
+func (b *SSEBroadcaster) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	metrics, err := parseMetrics(r.URL.Query().Get("metrics"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	lastRun, lastID, err := lastEventID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	client := &sseClient{
+		metrics: metrics,
+		events:  make(chan sseEvent, b.opts.ClientQueue),
+		dropped: make(chan struct{}),
+	}
+	backlog, gap, ok := b.attach(client, lastRun, lastID)
+	if !ok {
+		http.Error(w, "broadcaster closed", http.StatusServiceUnavailable)
+		return
+	}
+	defer b.detach(client)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	rc := http.NewResponseController(w)
+
+	if gap {
+		fmt.Fprint(w, "event: gap\ndata: {}\n\n")
+	}
+	for _, ev := range backlog {
+		if err := b.writeEvent(w, ev, metrics); err != nil {
+			return
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(b.opts.Heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case ev := <-client.events:
+			err = b.writeEvent(w, ev, metrics)
+		case <-heartbeat.C:
+			_, err = fmt.Fprint(w, ": heartbeat\n\n")
+		case <-client.dropped:
+			return
+		case <-b.closed:
+			return
+		case <-r.Context().Done():
+			return
+		}
+		if err == nil {
+			err = rc.Flush()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// attach registers client and returns the buffered events after lastID of run lastRun;
+// an empty lastRun means a fresh client. Both happen under one lock, so no event is sent
+// twice or falls between backlog and live stream. gap reports that the client's state
+// belongs to another run, or that events after lastID have already left the buffer.
+func (b *SSEBroadcaster) attach(client *sseClient, lastRun string, lastID uint64) (backlog []sseEvent, gap, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	select {
+	case <-b.closed:
+		return nil, false, false
+	default:
+	}
+	b.clients[client] = struct{}{}
+	if lastRun == "" {
+		return nil, false, true
+	}
+	if lastRun != b.run {
+		return nil, true, true
+	}
+	for _, ev := range b.history {
+		if ev.id > lastID {
+			backlog = append(backlog, ev)
+		}
+	}
+	switch {
+	case lastID >= b.nextID:
+		gap = true
+	case lastID+1 < b.nextID:
+		gap = len(b.history) == 0 || b.history[0].id > lastID+1
+	}
+	return backlog, gap, true
+}
+
+func (b *SSEBroadcaster) detach(client *sseClient) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.clients, client)
+}
+
+func (b *SSEBroadcaster) writeEvent(w http.ResponseWriter, ev sseEvent, metrics []Metric) error {
+	data := map[string]any{"time": ev.at.UTC().Format(time.RFC3339Nano)}
+	for _, m := range metrics {
+		data[m.String()] = m.Of(ev.reading)
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s-%d\nevent: reading\ndata: %s\n\n", b.run, ev.id, payload)
+	return err
+}
+
+// parseMetrics parses a comma-separated metric list; empty means all metrics.
+func parseMetrics(list string) ([]Metric, error) {
+	all := []Metric{Temperature, Humidity, Pressure}
+	if list == "" {
+		return all, nil
+	}
+	var metrics []Metric
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		found := false
+		for _, m := range all {
+			if m.String() == name {
+				metrics = append(metrics, m)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown metric %q", name)
+		}
+	}
+	return metrics, nil
+}
+
+// lastEventID reads the resume point from the Last-Event-ID header, or the lastEventId
+// query parameter for clients that cannot set headers, and splits it into run and
+// sequence number. Without one, run is empty.
+func lastEventID(r *http.Request) (run string, id uint64, err error) {
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("lastEventId")
+	}
+	if value == "" {
+		return "", 0, nil
+	}
+	run, seq, found := strings.Cut(value, "-")
+	if found && run != "" {
+		id, err = strconv.ParseUint(seq, 10, 64)
+	}
+	if !found || run == "" || err != nil {
+		return "", 0, fmt.Errorf("invalid Last-Event-ID %q", value)
+	}
+	return run, id, nil
+}
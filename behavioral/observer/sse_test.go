@@ -0,0 +1,266 @@
+package observer
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sseStream is the client side of one SSE connection.
+type sseStream struct {
+	t    *testing.T
+	resp *http.Response
+	r    *bufio.Reader
+}
+
+// sseMessage is one event, or one comment line when event is ":".
+type sseMessage struct {
+	id, event, data string
+}
+
+func connectSSE(t *testing.T, url, lastEventID string) *sseStream {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+	return &sseStream{t: t, resp: resp, r: bufio.NewReader(resp.Body)}
+}
+
+// next reads the next event or comment, failing the test after a second.
+func (s *sseStream) next() sseMessage {
+	s.t.Helper()
+	type result struct {
+		msg sseMessage
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		var msg sseMessage
+		for {
+			line, err := s.r.ReadString('\n')
+			if err != nil {
+				done <- result{err: err}
+				return
+			}
+			line = strings.TrimSuffix(line, "\n")
+			field, value, _ := strings.Cut(line, ": ")
+			switch field {
+			case "":
+				if line == "" {
+					done <- result{msg: msg}
+					return
+				}
+				done <- result{msg: sseMessage{event: ":", data: value}} // Comment
+				return
+			case "id":
+				msg.id = value
+			case "event":
+				msg.event = value
+			case "data":
+				msg.data = value
+			}
+		}
+	}()
+	select {
+	case res := <-done:
+		if res.err != nil {
+			s.t.Fatalf("reading stream: %v", res.err)
+		}
+		return res.msg
+	case <-time.After(time.Second):
+		s.t.Fatal("no event within a second")
+		return sseMessage{}
+	}
+}
+
+var sseEpoch = time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+func publish(b *SSEBroadcaster, temperatures ...float64) {
+	for i, temperature := range temperatures {
+		b.UpdateAt(sseEpoch.Add(time.Duration(i)*time.Minute), Reading{Temperature: temperature, Humidity: 50, Pressure: 1013})
+	}
+}
+
+func TestSSEResume(t *testing.T) {
+	tests := []struct {
+		name        string
+		buffer      int
+		published   int
+		lastEventID string   // RUN stands for the broadcaster's run ID
+		want        []string // Expected sequence numbers, "gap" for a gap event
+	}{
+		{"fresh client gets no backlog", 8, 3, "", nil},
+		{"resume within the buffer", 8, 3, "RUN-1", []string{"2", "3"}},
+		{"up to date", 8, 3, "RUN-3", nil},
+		{"buffer overrun", 2, 5, "RUN-1", []string{"gap", "4", "5"}},
+		{"last buffered event", 2, 5, "RUN-3", []string{"4", "5"}},
+		{"nothing buffered yet", 8, 0, "RUN-0", nil},
+		{"id ahead of this run", 8, 3, "RUN-99", []string{"gap"}},
+		{"id from another run", 8, 3, "5f3a9c0e21d4b7a8-2", []string{"gap"}},
+		{"id from another run, behind this one", 8, 0, "5f3a9c0e21d4b7a8-0", []string{"gap"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewSSEBroadcaster("sse", SSEOptions{ResumeBuffer: tt.buffer})
+			srv := httptest.NewServer(b)
+			defer srv.Close()
+			defer b.Close()
+			for i := range tt.published {
+				publish(b, float64(60+i))
+			}
+
+			stream := connectSSE(t, srv.URL, strings.Replace(tt.lastEventID, "RUN", b.run, 1))
+			for _, want := range tt.want {
+				msg := stream.next()
+				isGap := msg.event == "gap"
+				if (want == "gap") != isGap || !isGap && msg.id != b.run+"-"+want {
+					t.Fatalf("got event %+v, want %s", msg, want)
+				}
+			}
+			// The backlog is followed directly by the live stream.
+			publish(b, 99)
+			if msg := stream.next(); msg.event != "reading" || !strings.Contains(msg.data, `"temperature":99`) {
+				t.Errorf("live event = %+v, want the reading at 99", msg)
+			}
+		})
+	}
+}
+
+func TestSSEResumeAfterRestart(t *testing.T) {
+	// The first run issues IDs 1 to 3 and the client has seen up to 2.
+	before := NewSSEBroadcaster("sse", SSEOptions{})
+	publish(before, 60, 61, 62)
+	lastEventID := before.run + "-2"
+
+	// After a restart the new run has already passed that sequence number, so
+	// comparing numbers alone would look like a resume within the buffer.
+	after := NewSSEBroadcaster("sse", SSEOptions{})
+	if after.run == before.run {
+		t.Fatalf("two broadcasters share run ID %q", after.run)
+	}
+	publish(after, 70, 71, 72, 73, 74)
+	srv := httptest.NewServer(after)
+	defer srv.Close()
+	defer after.Close()
+
+	stream := connectSSE(t, srv.URL, lastEventID)
+	if msg := stream.next(); msg.event != "gap" {
+		t.Fatalf("first event = %+v, want a gap", msg)
+	}
+	publish(after, 99)
+	if msg := stream.next(); msg.id != after.run+"-6" || !strings.Contains(msg.data, `"temperature":99`) {
+		t.Errorf("event after the gap = %+v, want the live reading 6 at 99", msg)
+	}
+}
+
+func TestSSEMetricsAndPayload(t *testing.T) {
+	b := NewSSEBroadcaster("sse", SSEOptions{})
+	srv := httptest.NewServer(b)
+	defer srv.Close()
+	defer b.Close()
+
+	stream := connectSSE(t, srv.URL+"?metrics=temperature,Pressure", "")
+	publish(b, 80)
+	msg := stream.next()
+	want := sseMessage{id: b.run + "-1", event: "reading", data: `{"pressure":1013,"temperature":80,"time":"2024-05-01T12:00:00Z"}`}
+	if msg != want {
+		t.Errorf("event = %+v, want %+v", msg, want)
+	}
+}
+
+func TestSSERejectsBadRequests(t *testing.T) {
+	b := NewSSEBroadcaster("sse", SSEOptions{})
+	srv := httptest.NewServer(b)
+	defer srv.Close()
+	tests := []struct {
+		method, query, lastEventID string
+		want                       int
+	}{
+		{http.MethodPost, "", "", http.StatusMethodNotAllowed},
+		{http.MethodGet, "?metrics=wind", "", http.StatusBadRequest},
+		{http.MethodGet, "", "abc", http.StatusBadRequest},
+		{http.MethodGet, "", "42", http.StatusBadRequest},
+		{http.MethodGet, "", "run-x", http.StatusBadRequest},
+		{http.MethodGet, "?lastEventId=-1", "", http.StatusBadRequest},
+		{http.MethodGet, "?lastEventId=run--1", "", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(tt.method, srv.URL+tt.query, nil)
+		if tt.lastEventID != "" {
+			req.Header.Set("Last-Event-ID", tt.lastEventID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.want {
+			t.Errorf("%s %s (Last-Event-ID %q): status %d, want %d", tt.method, tt.query, tt.lastEventID, resp.StatusCode, tt.want)
+		}
+	}
+
+	b.Close()
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("after Close: status %d, want 503", resp.StatusCode)
+	}
+}
+
+func TestSSEHeartbeatAndClose(t *testing.T) {
+	b := NewSSEBroadcaster("sse", SSEOptions{Heartbeat: 10 * time.Millisecond})
+	srv := httptest.NewServer(b)
+	defer srv.Close()
+
+	stream := connectSSE(t, srv.URL, "")
+	if msg := stream.next(); msg.event != ":" || msg.data != "heartbeat" {
+		t.Errorf("first message = %+v, want a heartbeat comment", msg)
+	}
+	if n := b.Clients(); n != 1 {
+		t.Errorf("Clients = %d, want 1", n)
+	}
+	b.Close()
+	if _, err := io.ReadAll(stream.r); err != nil {
+		t.Errorf("stream did not end cleanly: %v", err)
+	}
+}
+
+func TestSSEDropsSlowClient(t *testing.T) {
+	b := NewSSEBroadcaster("sse", SSEOptions{ClientQueue: 2})
+	client := &sseClient{events: make(chan sseEvent, 2), dropped: make(chan struct{})}
+	if _, _, ok := b.attach(client, "", 0); !ok {
+		t.Fatal("attach failed")
+	}
+	publish(b, 1, 2)
+	if n := b.Clients(); n != 1 {
+		t.Fatalf("client dropped with room in its queue")
+	}
+	publish(b, 3)
+	select {
+	case <-client.dropped:
+	default:
+		t.Fatal("client with a full queue was not dropped")
+	}
+	if n := b.Clients(); n != 0 {
+		t.Errorf("Clients = %d after the drop, want 0", n)
+	}
+}
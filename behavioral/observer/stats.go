@@ -0,0 +1,136 @@
+package observer
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// --- Rolling statistics ---
+
+// Stats summarises one metric over a StatisticsDisplay's window.
+type Stats struct {
+	Count int
+	Min   float64
+	Max   float64
+	Mean  float64
+}
+
+type sample struct {
+	at      time.Time
+	reading Reading
+}
+
+// StatisticsDisplay keeps the readings of the last window and computes min, max,
+// mean and percentiles per metric. It is a TimedDisplay, so samples carry the time the
+// station published them. All values are in StationUnits; convert them with Measurement.In to show them in others.
+type StatisticsDisplay struct {
+	name   string
+	window time.Duration
+
+	mu      sync.Mutex
+	now     func() time.Time
+	samples []sample // Oldest first
+}
+
+func NewStatisticsDisplay(name string, window time.Duration) *StatisticsDisplay {
+	return &StatisticsDisplay{name: name, window: window, now: time.Now}
+}
+
+// SetClock replaces the time source that ends the window, so tests and replays control it.
+func (s *StatisticsDisplay) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+func (s *StatisticsDisplay) GetName() string {
+	return s.name
+}
+
+// Update records a reading stamped with the display's clock. The station calls UpdateAt
+// instead, so readings that waited in a delivery queue keep their publish time.
+func (s *StatisticsDisplay) Update(temperature, humidity, pressure float64) {
+	s.mu.Lock()
+	at := s.now()
+	s.mu.Unlock()
+	s.UpdateAt(at, Reading{Temperature: temperature, Humidity: humidity, Pressure: pressure})
+}
+
+// UpdateAt records a reading published at at. Samples are kept in time order, so one
+// that arrives late still leaves the window when its own time runs out.
+func (s *StatisticsDisplay) UpdateAt(at time.Time, r Reading) {
+	s.mu.Lock()
+	i := len(s.samples)
+	for i > 0 && s.samples[i-1].at.After(at) {
+		i--
+	}
+	s.samples = slices.Insert(s.samples, i, sample{at: at, reading: r})
+	s.evictLocked()
+	stats := s.statsLocked(Temperature)
+	s.mu.Unlock()
+	fmt.Printf("[%s] Avg/Max/Min temperature over %d readings: %.1f/%.1f/%.1f\n",
+		s.name, stats.Count, stats.Mean, stats.Max, stats.Min)
+}
+
+// Stats returns the summary of metric over the current window.
+// A zero Count means there are no readings in the window.
+func (s *StatisticsDisplay) Stats(metric Metric) Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evictLocked()
+	return s.statsLocked(metric)
+}
+
+// Percentile returns the p-th percentile (0-100) of metric over the current window,
+// interpolating between the closest ranks. It returns NaN for an empty window.
+func (s *StatisticsDisplay) Percentile(metric Metric, p float64) float64 {
+	s.mu.Lock()
+	values := s.valuesLocked(metric)
+	s.mu.Unlock()
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	slices.Sort(values)
+	rank := min(max(p, 0), 100) / 100 * float64(len(values)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return values[lower] + (values[upper]-values[lower])*(rank-float64(lower))
+}
+
+// evictLocked drops samples older than the window. Callers must hold s.mu.
+func (s *StatisticsDisplay) evictLocked() {
+	cutoff := s.now().Add(-s.window)
+	i := 0
+	for i < len(s.samples) && s.samples[i].at.Before(cutoff) {
+		i++
+	}
+	s.samples = s.samples[i:]
+}
+
+func (s *StatisticsDisplay) valuesLocked(metric Metric) []float64 {
+	s.evictLocked()
+	values := make([]float64, len(s.samples))
+	for i, smp := range s.samples {
+		values[i] = metric.Of(smp.reading)
+	}
+	return values
+}
+
+func (s *StatisticsDisplay) statsLocked(metric Metric) Stats {
+	values := s.valuesLocked(metric)
+	if len(values) == 0 {
+		return Stats{}
+	}
+	stats := Stats{Count: len(values), Min: values[0], Max: values[0]}
+	sum := 0.0
+	for _, v := range values {
+		stats.Min = min(stats.Min, v)
+		stats.Max = max(stats.Max, v)
+		sum += v
+	}
+	stats.Mean = sum / float64(len(values))
+	return stats
+}
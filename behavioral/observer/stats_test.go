@@ -0,0 +1,168 @@
+package observer
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func near(got, want, tolerance float64) bool {
+	return math.Abs(got-want) <= tolerance
+}
+
+func TestStatisticsDisplay(t *testing.T) {
+	start := time.Date(2024, time.January, 5, 6, 0, 0, 0, time.UTC)
+	type reading struct {
+		at          time.Duration // After start
+		temperature float64
+	}
+	tests := []struct {
+		name     string
+		readings []reading
+		queryAt  time.Duration
+		want     Stats
+		wantP50  float64
+		wantP90  float64
+	}{
+		{"empty", nil, 0, Stats{}, math.NaN(), math.NaN()},
+		{"single", []reading{{0, 70}}, 0, Stats{Count: 1, Min: 70, Max: 70, Mean: 70}, 70, 70},
+		{"several", []reading{{0, 60}, {time.Minute, 80}, {2 * time.Minute, 70}, {3 * time.Minute, 90}},
+			3 * time.Minute, Stats{Count: 4, Min: 60, Max: 90, Mean: 75}, 75, 87},
+		{"old readings leave the window", []reading{{0, 10}, {30 * time.Minute, 60}, {50 * time.Minute, 80}},
+			70 * time.Minute, Stats{Count: 2, Min: 60, Max: 80, Mean: 70}, 70, 78},
+		{"window edge is inclusive", []reading{{0, 10}, {time.Hour, 30}}, time.Hour, Stats{Count: 2, Min: 10, Max: 30, Mean: 20}, 20, 28},
+		{"everything expired", []reading{{0, 10}}, 2 * time.Hour, Stats{}, math.NaN(), math.NaN()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := start
+			s := NewStatisticsDisplay("stats", time.Hour)
+			s.SetClock(func() time.Time { return now })
+			for _, r := range tt.readings {
+				now = start.Add(r.at)
+				s.Update(r.temperature, 50, 1013)
+			}
+			now = start.Add(tt.queryAt)
+			if got := s.Stats(Temperature); got != tt.want {
+				t.Errorf("Stats = %+v, want %+v", got, tt.want)
+			}
+			for p, want := range map[float64]float64{50: tt.wantP50, 90: tt.wantP90} {
+				got := s.Percentile(Temperature, p)
+				if math.IsNaN(want) != math.IsNaN(got) || (!math.IsNaN(want) && !near(got, want, 1e-9)) {
+					t.Errorf("Percentile(%g) = %g, want %g", p, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestStatisticsPerMetric(t *testing.T) {
+	s := NewStatisticsDisplay("stats", time.Hour)
+	s.Update(70, 40, 1010)
+	s.Update(72, 60, 1020)
+	for metric, want := range map[Metric]Stats{
+		Temperature: {Count: 2, Min: 70, Max: 72, Mean: 71},
+		Humidity:    {Count: 2, Min: 40, Max: 60, Mean: 50},
+		Pressure:    {Count: 2, Min: 1010, Max: 1020, Mean: 1015},
+	} {
+		if got := s.Stats(metric); got != want {
+			t.Errorf("Stats(%s) = %+v, want %+v", metric, got, want)
+		}
+	}
+}
+
+func TestComfort(t *testing.T) {
+	tests := []struct {
+		fahrenheit, humidity float64
+		wantHeatIndex        float64 // As on the NWS heat index chart, to 0.1°F
+		wantDewPoint         float64
+	}{
+		{70, 50, 69.0, 50.5}, // Below 80°F the simple formula applies
+		{90, 70, 105.9, 78.9},
+		{100, 40, 109.3, 71.3},
+		{82, 95, 94.0, 80.4},  // High-humidity adjustment
+		{100, 10, 94.1, 33.7}, // Low-humidity adjustment
+		{68, 100, 69.2, 68.0}, // Saturated air: the dew point is the temperature
+		{40, 80, 37.5, 34.3},
+	}
+	for _, tt := range tests {
+		c := NewComfortDisplay("comfort")
+		c.Update(tt.fahrenheit, tt.humidity, 1013)
+		if got := c.HeatIndex(); !near(got, tt.wantHeatIndex, 0.1) {
+			t.Errorf("heat index at %g°F, %g%% = %.2f, want %.1f", tt.fahrenheit, tt.humidity, got, tt.wantHeatIndex)
+		}
+		if got := c.DewPoint(); !near(got, tt.wantDewPoint, 0.1) {
+			t.Errorf("dew point at %g°F, %g%% = %.2f, want %.1f", tt.fahrenheit, tt.humidity, got, tt.wantDewPoint)
+		}
+	}
+}
+
+func TestForecastTrend(t *testing.T) {
+	tests := []struct {
+		name      string
+		readings  int
+		pressures []float64
+		want      Trend
+	}{
+		{"no readings", 3, nil, Steady},
+		{"one reading", 3, []float64{1013}, Steady},
+		{"rising", 3, []float64{1005, 1008, 1011}, Rising},
+		{"falling", 3, []float64{1011, 1008, 1005}, Falling},
+		{"small wobble", 3, []float64{1013, 1013.2, 1012.9}, Steady},
+		{"only the last readings count", 3, []float64{1020, 1010, 1000, 1001, 1003, 1005}, Rising},
+		{"longer window sees the fall", 6, []float64{1020, 1010, 1000, 1001, 1003, 1005}, Falling},
+		{"relative threshold works in inHg", 3, []float64{29.8, 29.9, 30.0}, Rising},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewTrendForecastDisplay("forecast", tt.readings)
+			for _, p := range tt.pressures {
+				f.Update(70, 50, p)
+			}
+			if got := f.Trend(); got != tt.want {
+				t.Errorf("Trend = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatisticsUseThePublishTime(t *testing.T) {
+	start := time.Date(2024, time.January, 5, 6, 0, 0, 0, time.UTC)
+	now := start
+	s := NewStatisticsDisplay("stats", time.Hour)
+	s.SetClock(func() time.Time { return now })
+
+	// Readings that arrive late and out of order still expire by their own time.
+	now = start.Add(time.Hour)
+	s.UpdateAt(start.Add(50*time.Minute), Reading{Temperature: 50})
+	s.UpdateAt(start.Add(10*time.Minute), Reading{Temperature: 10})
+	s.UpdateAt(start.Add(30*time.Minute), Reading{Temperature: 30})
+	s.UpdateAt(start.Add(-time.Minute), Reading{Temperature: -1}) // Already outside the window
+	for _, tt := range []struct {
+		at   time.Duration
+		want Stats
+	}{
+		{time.Hour, Stats{Count: 3, Min: 10, Max: 50, Mean: 30}},
+		{75 * time.Minute, Stats{Count: 2, Min: 30, Max: 50, Mean: 40}},
+		{95 * time.Minute, Stats{Count: 1, Min: 50, Max: 50, Mean: 50}},
+	} {
+		now = start.Add(tt.at)
+		if got := s.Stats(Temperature); got != tt.want {
+			t.Errorf("at %v Stats = %+v, want %+v", tt.at, got, tt.want)
+		}
+	}
+
+	// Through a station, the sample is stamped when the reading is published,
+	// not by the display's clock when it is delivered.
+	published := time.Now()
+	s = NewStatisticsDisplay("stats", time.Hour)
+	s.SetClock(func() time.Time { return published.Add(10 * time.Minute) })
+	ws := NewWeatherStation()
+	ws.RegisterObserver(s)
+	ws.SetMeasurements(20, 50, 1013)
+	ws.Close()
+	s.SetClock(func() time.Time { return published.Add(65 * time.Minute) })
+	if got := s.Stats(Temperature); got.Count != 0 {
+		t.Errorf("a reading published over an hour ago is still in the window: %+v", got)
+	}
+}
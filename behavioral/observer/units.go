@@ -0,0 +1,261 @@
+package observer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// --- Units of measurement ---
+// WeatherDisplay.Update and SetMeasurements take plain float64s in StationUnits.
+// Values from sensors in other units go through Measurement and SetReading, which
+// convert them, so a reading in inHg can never be mistaken for hPa.
+
+// Unit is a unit of temperature, humidity or pressure.
+type Unit int
+
+const (
+	// UnitDefault is the zero Unit. As a target unit it stands for the StationUnits
+	// unit of whatever is being converted; a Measurement cannot be in it.
+	UnitDefault Unit = iota
+	Fahrenheit
+	Celsius
+	Kelvin
+	RelativeHumidity // Percent, 0-100
+	AbsoluteHumidity // Grams of water vapour per cubic metre of air
+	Hectopascal
+	Kilopascal
+	InchesOfMercury
+)
+
+// Units is a preferred unit per metric. A field left at UnitDefault keeps that
+// metric in StationUnits, so Units{Temperature: Celsius} shows °C, % and hPa.
+type Units struct {
+	Temperature Unit
+	Humidity    Unit
+	Pressure    Unit
+}
+
+// StationUnits are the units of the float64 values passed to SetMeasurements and Update.
+var StationUnits = Units{Temperature: Fahrenheit, Humidity: RelativeHumidity, Pressure: Hectopascal}
+
+// MetricUnits is a convenient choice for displays outside the US.
+var MetricUnits = Units{Temperature: Celsius, Humidity: RelativeHumidity, Pressure: Hectopascal}
+
+// ErrIncompatibleUnits is returned when converting between units of different metrics.
+var ErrIncompatibleUnits = errors.New("incompatible units")
+
+// ErrNoUnit is returned for a Measurement whose Unit was left at UnitDefault.
+var ErrNoUnit = errors.New("measurement has no unit")
+
+// unitFor returns the unit u prefers for metric, resolving UnitDefault to StationUnits.
+func (u Units) unitFor(metric Metric) Unit {
+	var unit, station Unit
+	switch metric {
+	case Humidity:
+		unit, station = u.Humidity, StationUnits.Humidity
+	case Pressure:
+		unit, station = u.Pressure, StationUnits.Pressure
+	default:
+		unit, station = u.Temperature, StationUnits.Temperature
+	}
+	if unit == UnitDefault {
+		return station
+	}
+	return unit
+}
+
+const hPaPerInHg = 33.8638866667
+
+func (u Unit) String() string {
+	switch u {
+	case UnitDefault:
+		return "default"
+	case Fahrenheit:
+		return "°F"
+	case Celsius:
+		return "°C"
+	case Kelvin:
+		return "K"
+	case RelativeHumidity:
+		return "%"
+	case AbsoluteHumidity:
+		return "g/m³"
+	case Hectopascal:
+		return "hPa"
+	case Kilopascal:
+		return "kPa"
+	case InchesOfMercury:
+		return "inHg"
+	default:
+		return "unit(" + strconv.Itoa(int(u)) + ")"
+	}
+}
+
+// Metric reports what the unit measures. UnitDefault reports Temperature, so check for it
+// before relying on the answer.
+func (u Unit) Metric() Metric {
+	switch u {
+	case RelativeHumidity, AbsoluteHumidity:
+		return Humidity
+	case Hectopascal, Kilopascal, InchesOfMercury:
+		return Pressure
+	default:
+		return Temperature
+	}
+}
+
+// Measurement is a value with an explicit unit.
+type Measurement struct {
+	Value float64
+	Unit  Unit
+}
+
+func (m Measurement) String() string {
+	switch m.Unit {
+	case Fahrenheit, Celsius, RelativeHumidity:
+		return fmt.Sprintf("%.1f%s", m.Value, m.Unit)
+	case InchesOfMercury, Kilopascal:
+		return fmt.Sprintf("%.2f %s", m.Value, m.Unit)
+	default:
+		return fmt.Sprintf("%.1f %s", m.Value, m.Unit)
+	}
+}
+
+// In converts m to unit, or to its StationUnits unit for UnitDefault. Relative and
+// absolute humidity cannot be converted without a temperature; use HumidityIn for those.
+func (m Measurement) In(unit Unit) (Measurement, error) {
+	if m.Unit == UnitDefault {
+		return Measurement{}, ErrNoUnit
+	}
+	if unit == UnitDefault {
+		unit = StationUnits.unitFor(m.Unit.Metric())
+	}
+	if m.Unit == unit {
+		return m, nil
+	}
+	if m.Unit.Metric() != unit.Metric() || unit.Metric() == Humidity {
+		return Measurement{}, fmt.Errorf("convert %s to %s: %w", m.Unit, unit, ErrIncompatibleUnits)
+	}
+	switch unit.Metric() {
+	case Temperature:
+		return Measurement{Value: fromKelvin(m.kelvin(), unit), Unit: unit}, nil
+	default:
+		return Measurement{Value: fromHectopascal(m.hectopascal(), unit), Unit: unit}, nil
+	}
+}
+
+// HumidityIn converts a humidity to unit, using temperature to go between
+// relative and absolute humidity.
+func (m Measurement) HumidityIn(unit Unit, temperature Measurement) (Measurement, error) {
+	if m.Unit == UnitDefault {
+		return Measurement{}, ErrNoUnit
+	}
+	if unit == UnitDefault {
+		unit = StationUnits.Humidity
+	}
+	if m.Unit.Metric() != Humidity || unit.Metric() != Humidity {
+		return Measurement{}, fmt.Errorf("convert %s to %s: %w", m.Unit, unit, ErrIncompatibleUnits)
+	}
+	if m.Unit == unit {
+		return m, nil
+	}
+	celsius, err := temperature.In(Celsius)
+	if err != nil {
+		return Measurement{}, err
+	}
+	// Grams of water per cubic metre at saturation (Magnus formula for vapour pressure).
+	saturated := 6.112 * math.Exp(17.67*celsius.Value/(celsius.Value+243.5)) * 216.74 / (273.15 + celsius.Value)
+	if unit == AbsoluteHumidity {
+		return Measurement{Value: m.Value / 100 * saturated, Unit: AbsoluteHumidity}, nil
+	}
+	return Measurement{Value: m.Value / saturated * 100, Unit: RelativeHumidity}, nil
+}
+
+func (m Measurement) kelvin() float64 {
+	switch m.Unit {
+	case Celsius:
+		return m.Value + 273.15
+	case Kelvin:
+		return m.Value
+	default:
+		return (m.Value-32)*5/9 + 273.15
+	}
+}
+
+func fromKelvin(k float64, unit Unit) float64 {
+	switch unit {
+	case Celsius:
+		return k - 273.15
+	case Kelvin:
+		return k
+	default:
+		return (k-273.15)*9/5 + 32
+	}
+}
+
+func (m Measurement) hectopascal() float64 {
+	switch m.Unit {
+	case Kilopascal:
+		return m.Value * 10
+	case InchesOfMercury:
+		return m.Value * hPaPerInHg
+	default:
+		return m.Value
+	}
+}
+
+func fromHectopascal(hPa float64, unit Unit) float64 {
+	switch unit {
+	case Kilopascal:
+		return hPa / 10
+	case InchesOfMercury:
+		return hPa / hPaPerInHg
+	default:
+		return hPa
+	}
+}
+
+// NewReading converts unit-tagged values to a Reading in StationUnits. It fails if a
+// value is in a unit of the wrong metric or is physically impossible.
+func NewReading(temperature, humidity, pressure Measurement) (Reading, error) {
+	if temperature.Unit == UnitDefault || humidity.Unit == UnitDefault || pressure.Unit == UnitDefault {
+		return Reading{}, fmt.Errorf("reading: %w", ErrNoUnit)
+	}
+	if temperature.Unit.Metric() != Temperature || humidity.Unit.Metric() != Humidity || pressure.Unit.Metric() != Pressure {
+		return Reading{}, fmt.Errorf("reading needs temperature, humidity and pressure units, got %s, %s and %s: %w",
+			temperature.Unit, humidity.Unit, pressure.Unit, ErrIncompatibleUnits)
+	}
+	if temperature.kelvin() < 0 {
+		return Reading{}, fmt.Errorf("temperature %v is below absolute zero", temperature)
+	}
+	if pressure.Value <= 0 {
+		return Reading{}, fmt.Errorf("pressure %v must be positive", pressure)
+	}
+	t, _ := temperature.In(StationUnits.Temperature)
+	h, err := humidity.HumidityIn(StationUnits.Humidity, temperature)
+	if err != nil {
+		return Reading{}, err
+	}
+	if h.Value < 0 || h.Value > 100 {
+		return Reading{}, fmt.Errorf("relative humidity %v is out of range", h)
+	}
+	p, _ := pressure.In(StationUnits.Pressure)
+	return Reading{Temperature: t.Value, Humidity: h.Value, Pressure: p.Value}, nil
+}
+
+// In expresses a reading (in StationUnits) in the given units. Metrics left at
+// UnitDefault stay in StationUnits.
+func (r Reading) In(units Units) (temperature, humidity, pressure Measurement, err error) {
+	stationTemperature := Measurement{Value: r.Temperature, Unit: StationUnits.Temperature}
+	if temperature, err = stationTemperature.In(units.unitFor(Temperature)); err != nil {
+		return
+	}
+	stationHumidity := Measurement{Value: r.Humidity, Unit: StationUnits.Humidity}
+	if humidity, err = stationHumidity.HumidityIn(units.unitFor(Humidity), stationTemperature); err != nil {
+		return
+	}
+	pressure, err = Measurement{Value: r.Pressure, Unit: StationUnits.Pressure}.In(units.unitFor(Pressure))
+	return
+}
@@ -0,0 +1,137 @@
+package observer
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMeasurementIn(t *testing.T) {
+	tests := []struct {
+		from    Measurement
+		to      Unit
+		want    Measurement
+		wantErr error
+	}{
+		{Measurement{212, Fahrenheit}, Celsius, Measurement{100, Celsius}, nil},
+		{Measurement{0, Celsius}, Kelvin, Measurement{273.15, Kelvin}, nil},
+		{Measurement{0, Kelvin}, Fahrenheit, Measurement{-459.67, Fahrenheit}, nil},
+		{Measurement{30, InchesOfMercury}, Hectopascal, Measurement{1015.92, Hectopascal}, nil},
+		{Measurement{101.3, Kilopascal}, InchesOfMercury, Measurement{29.91, InchesOfMercury}, nil},
+		{Measurement{20, Celsius}, UnitDefault, Measurement{68, Fahrenheit}, nil},
+		{Measurement{101.3, Kilopascal}, UnitDefault, Measurement{1013, Hectopascal}, nil},
+		{Measurement{30, InchesOfMercury}, Celsius, Measurement{}, ErrIncompatibleUnits},
+		{Measurement{50, RelativeHumidity}, AbsoluteHumidity, Measurement{}, ErrIncompatibleUnits},
+		{Measurement{Value: 20}, Celsius, Measurement{}, ErrNoUnit},
+	}
+	for _, tt := range tests {
+		got, err := tt.from.In(tt.to)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%v in %s: error %v, want %v", tt.from, tt.to, err, tt.wantErr)
+			continue
+		}
+		if got.Unit != tt.want.Unit || !near(got.Value, tt.want.Value, 0.01) {
+			t.Errorf("%v in %s = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestHumidityIn(t *testing.T) {
+	tests := []struct {
+		from        Measurement
+		to          Unit
+		temperature Measurement
+		want        Measurement
+	}{
+		{Measurement{100, RelativeHumidity}, AbsoluteHumidity, Measurement{20, Celsius}, Measurement{17.3, AbsoluteHumidity}},
+		{Measurement{50, RelativeHumidity}, AbsoluteHumidity, Measurement{86, Fahrenheit}, Measurement{15.2, AbsoluteHumidity}},
+		{Measurement{8.65, AbsoluteHumidity}, RelativeHumidity, Measurement{293.15, Kelvin}, Measurement{50, RelativeHumidity}},
+		{Measurement{8.65, AbsoluteHumidity}, UnitDefault, Measurement{20, Celsius}, Measurement{50, RelativeHumidity}},
+	}
+	for _, tt := range tests {
+		got, err := tt.from.HumidityIn(tt.to, tt.temperature)
+		if err != nil {
+			t.Errorf("%v in %s at %v: %v", tt.from, tt.to, tt.temperature, err)
+			continue
+		}
+		if got.Unit != tt.want.Unit || !near(got.Value, tt.want.Value, 0.1) {
+			t.Errorf("%v in %s at %v = %v, want %v", tt.from, tt.to, tt.temperature, got, tt.want)
+		}
+	}
+}
+
+func TestReadingIn(t *testing.T) {
+	r := Reading{Temperature: 68, Humidity: 50, Pressure: 1013.25}
+	tests := []struct {
+		name                string
+		units               Units
+		wantT, wantH, wantP Measurement
+	}{
+		{"zero value is StationUnits", Units{}, Measurement{68, Fahrenheit}, Measurement{50, RelativeHumidity}, Measurement{1013.25, Hectopascal}},
+		{"only temperature", Units{Temperature: Celsius}, Measurement{20, Celsius}, Measurement{50, RelativeHumidity}, Measurement{1013.25, Hectopascal}},
+		{"only pressure", Units{Pressure: InchesOfMercury}, Measurement{68, Fahrenheit}, Measurement{50, RelativeHumidity}, Measurement{29.92, InchesOfMercury}},
+		{"metric", MetricUnits, Measurement{20, Celsius}, Measurement{50, RelativeHumidity}, Measurement{1013.25, Hectopascal}},
+		{"all set", Units{Kelvin, AbsoluteHumidity, Kilopascal}, Measurement{293.15, Kelvin}, Measurement{8.64, AbsoluteHumidity}, Measurement{101.33, Kilopascal}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotT, gotH, gotP, err := r.In(tt.units)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range []struct{ got, want Measurement }{{gotT, tt.wantT}, {gotH, tt.wantH}, {gotP, tt.wantP}} {
+				if c.got.Unit != c.want.Unit || !near(c.got.Value, c.want.Value, 0.01) {
+					t.Errorf("got %v, want %v", c.got, c.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCurrentConditionsWithPartialUnits(t *testing.T) {
+	display := NewCurrentConditionsDisplay("conditions")
+	display.SetUnits(Units{Temperature: Celsius})
+	display.Update(50, 65, 1013)
+	temperature, humidity := display.Conditions()
+	if temperature.Unit != Celsius || !near(temperature.Value, 10, 1e-9) {
+		t.Errorf("temperature = %v, want 10.0°C", temperature)
+	}
+	if humidity != (Measurement{65, RelativeHumidity}) {
+		t.Errorf("humidity = %v, want 65.0%%", humidity)
+	}
+}
+
+func TestNewReading(t *testing.T) {
+	tests := []struct {
+		name                  string
+		temperature, humidity Measurement
+		pressure              Measurement
+		want                  Reading
+		wantErr               error // nil when any error will do, with wantFail
+		wantFail              bool
+	}{
+		{"converted", Measurement{20, Celsius}, Measurement{50, RelativeHumidity}, Measurement{30, InchesOfMercury},
+			Reading{68, 50, 1015.92}, nil, false},
+		{"absolute humidity", Measurement{20, Celsius}, Measurement{8.65, AbsoluteHumidity}, Measurement{1000, Hectopascal},
+			Reading{68, 50, 1000}, nil, false},
+		{"missing unit", Measurement{Value: 70}, Measurement{50, RelativeHumidity}, Measurement{1013, Hectopascal},
+			Reading{}, ErrNoUnit, true},
+		{"pressure as temperature", Measurement{20, Celsius}, Measurement{50, RelativeHumidity}, Measurement{20, Celsius},
+			Reading{}, ErrIncompatibleUnits, true},
+		{"below absolute zero", Measurement{-300, Celsius}, Measurement{50, RelativeHumidity}, Measurement{1013, Hectopascal},
+			Reading{}, nil, true},
+		{"supersaturated", Measurement{0, Celsius}, Measurement{20, AbsoluteHumidity}, Measurement{1013, Hectopascal},
+			Reading{}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewReading(tt.temperature, tt.humidity, tt.pressure)
+			if (err != nil) != tt.wantFail || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+				t.Fatalf("error = %v, want %v (fail %t)", err, tt.wantErr, tt.wantFail)
+			}
+			if !near(got.Temperature, tt.want.Temperature, 0.01) || !near(got.Humidity, tt.want.Humidity, 0.1) ||
+				!near(got.Pressure, tt.want.Pressure, 0.01) {
+				t.Errorf("reading = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,4 +1,4 @@
-package main
+package state
 
 import "fmt"
 
@@ -6,7 +6,7 @@ type HasItemState struct {
 	vendingMachine *VendingMachine
 }
 
-func (i *HasItemState) requestItem() error {
+func (i *HasItemState) RequestItem() error {
 	if i.vendingMachine.itemCount == 0 {
 		i.vendingMachine.setState(i.vendingMachine.noItem)
 		return fmt.Errorf("No item present")
@@ -16,15 +16,15 @@ func (i *HasItemState) requestItem() error {
 	return nil
 }
 
-func (i *HasItemState) addItem(count int) error {
+func (i *HasItemState) AddItem(count int) error {
 	fmt.Printf("%d items added\n", count)
 	i.vendingMachine.incrementItemCount(count)
 	return nil
 }
 
-func (i *HasItemState) insertMoney(money int) error {
+func (i *HasItemState) InsertMoney(money int) error {
 	return fmt.Errorf("Please select item first")
 }
-func (i *HasItemState) dispenseItem() error {
+func (i *HasItemState) DispenseItem() error {
 	return fmt.Errorf("Please select item first")
 }
@@ -1,4 +1,4 @@
-package main
+package state
 
 import "fmt"
 
@@ -6,19 +6,19 @@ type HasMoneyState struct {
 	vendingMachine *VendingMachine
 }
 
-func (i *HasMoneyState) requestItem() error {
+func (i *HasMoneyState) RequestItem() error {
 	return fmt.Errorf("Item dispense in progress")
 }
 
-func (i *HasMoneyState) addItem(count int) error {
+func (i *HasMoneyState) AddItem(count int) error {
 	return fmt.Errorf("Item dispense in progress")
 }
 
-func (i *HasMoneyState) insertMoney(money int) error {
+func (i *HasMoneyState) InsertMoney(money int) error {
 	return fmt.Errorf("Item out of stock")
 }
 
-func (i *HasMoneyState) dispenseItem() error {
+func (i *HasMoneyState) DispenseItem() error {
 	fmt.Println("Dispensing Item")
 	i.vendingMachine.itemCount = i.vendingMachine.itemCount - 1
 	if i.vendingMachine.itemCount == 0 {
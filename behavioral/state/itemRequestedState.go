@@ -1,4 +1,4 @@
-package main
+package state
 
 import "fmt"
 
@@ -6,15 +6,15 @@ type ItemRequestedState struct {
 	vendingMachine *VendingMachine
 }
 
-func (i *ItemRequestedState) requestItem() error {
+func (i *ItemRequestedState) RequestItem() error {
 	return fmt.Errorf("Item already requested")
 }
 
-func (i *ItemRequestedState) addItem(count int) error {
+func (i *ItemRequestedState) AddItem(count int) error {
 	return fmt.Errorf("Item Dispense in progress")
 }
 
-func (i *ItemRequestedState) insertMoney(money int) error {
+func (i *ItemRequestedState) InsertMoney(money int) error {
 	if money < i.vendingMachine.itemPrice {
 		return fmt.Errorf("Inserted money is less. Please insert %d", i.vendingMachine.itemPrice)
 	}
@@ -22,6 +22,6 @@ func (i *ItemRequestedState) insertMoney(money int) error {
 	i.vendingMachine.setState(i.vendingMachine.hasMoney)
 	return nil
 }
-func (i *ItemRequestedState) dispenseItem() error {
+func (i *ItemRequestedState) DispenseItem() error {
 	return fmt.Errorf("Please insert money first")
 }
@@ -1,4 +1,4 @@
-package main
+package state
 
 import "fmt"
 
@@ -6,19 +6,19 @@ type NoItemState struct {
 	vendingMachine *VendingMachine
 }
 
-func (i *NoItemState) requestItem() error {
+func (i *NoItemState) RequestItem() error {
 	return fmt.Errorf("Item out of stock")
 }
 
-func (i *NoItemState) addItem(count int) error {
+func (i *NoItemState) AddItem(count int) error {
 	i.vendingMachine.incrementItemCount(count)
 	i.vendingMachine.setState(i.vendingMachine.hasItem)
 	return nil
 }
 
-func (i *NoItemState) insertMoney(money int) error {
+func (i *NoItemState) InsertMoney(money int) error {
 	return fmt.Errorf("Item out of stock")
 }
-func (i *NoItemState) dispenseItem() error {
+func (i *NoItemState) DispenseItem() error {
 	return fmt.Errorf("Item out of stock")
 }
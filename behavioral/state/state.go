@@ -1,8 +1,8 @@
-package main
+package state
 
 type State interface {
-	addItem(int) error
-	requestItem() error
-	insertMoney(money int) error
-	dispenseItem() error
+	AddItem(int) error
+	RequestItem() error
+	InsertMoney(money int) error
+	DispenseItem() error
 }
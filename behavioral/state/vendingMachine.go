@@ -1,4 +1,4 @@
-package main
+package state
 
 import "fmt"
 
@@ -14,7 +14,7 @@ type VendingMachine struct {
 	itemPrice int
 }
 
-func newVendingMachine(itemCount, itemPrice int) *VendingMachine {
+func NewVendingMachine(itemCount, itemPrice int) *VendingMachine {
 	v := &VendingMachine{
 		itemCount: itemCount,
 		itemPrice: itemPrice,
@@ -42,20 +42,20 @@ func newVendingMachine(itemCount, itemPrice int) *VendingMachine {
 	return v
 }
 
-func (v *VendingMachine) requestItem() error {
-	return v.currentState.requestItem()
+func (v *VendingMachine) RequestItem() error {
+	return v.currentState.RequestItem()
 }
 
-func (v *VendingMachine) addItem(count int) error {
-	return v.currentState.addItem(count)
+func (v *VendingMachine) AddItem(count int) error {
+	return v.currentState.AddItem(count)
 }
 
-func (v *VendingMachine) insertMoney(money int) error {
-	return v.currentState.insertMoney(money)
+func (v *VendingMachine) InsertMoney(money int) error {
+	return v.currentState.InsertMoney(money)
 }
 
-func (v *VendingMachine) dispenseItem() error {
-	return v.currentState.dispenseItem()
+func (v *VendingMachine) DispenseItem() error {
+	return v.currentState.DispenseItem()
 }
 
 func (v *VendingMachine) setState(s State) {
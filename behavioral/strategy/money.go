@@ -0,0 +1,336 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// --- Money ---
+// Money counts integer minor units (cents, pence, yen) of an ISO 4217 currency,
+// so totals never pick up floating-point rounding errors.
+
+var (
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrOverflow         = errors.New("money amount overflows")
+	ErrUnknownCurrency  = errors.New("unknown currency")
+)
+
+// Currency is an ISO 4217 currency.
+type Currency struct {
+	Code   string // ISO 4217 alphabetic code, e.g. "USD"
+	Digits int    // Minor unit digits: 2 for USD, 0 for JPY, 3 for KWD
+	Symbol string // Optional prefix used when formatting, e.g. "$"
+}
+
+var (
+	USD = Currency{Code: "USD", Digits: 2, Symbol: "$"}
+	EUR = Currency{Code: "EUR", Digits: 2, Symbol: "€"}
+	GBP = Currency{Code: "GBP", Digits: 2, Symbol: "£"}
+	JPY = Currency{Code: "JPY", Digits: 0, Symbol: "¥"}
+	CHF = Currency{Code: "CHF", Digits: 2}
+	CAD = Currency{Code: "CAD", Digits: 2}
+	AUD = Currency{Code: "AUD", Digits: 2}
+	SEK = Currency{Code: "SEK", Digits: 2}
+	INR = Currency{Code: "INR", Digits: 2, Symbol: "₹"}
+	KWD = Currency{Code: "KWD", Digits: 3}
+)
+
+var currencies = map[string]Currency{}
+
+func init() {
+	for _, c := range []Currency{USD, EUR, GBP, JPY, CHF, CAD, AUD, SEK, INR, KWD} {
+		currencies[c.Code] = c
+	}
+}
+
+// LookupCurrency finds a known currency by its ISO 4217 code.
+func LookupCurrency(code string) (Currency, error) {
+	c, ok := currencies[strings.ToUpper(code)]
+	if !ok {
+		return Currency{}, fmt.Errorf("%w %q", ErrUnknownCurrency, code)
+	}
+	return c, nil
+}
+
+func (c Currency) String() string {
+	return c.Code
+}
+
+// Money is an amount of a currency. The zero value has no currency; use NewMoney.
+type Money struct {
+	amount   int64 // Minor units
+	currency Currency
+}
+
+// NewMoney returns minor units of currency, e.g. NewMoney(1050, USD) is $10.50.
+func NewMoney(minor int64, currency Currency) Money {
+	return Money{amount: minor, currency: currency}
+}
+
+// ParseMoney parses "USD 10.50", "10.50 USD" or, for currencies with a symbol, "$10.50".
+// The amount may use thousands separators ("$1,234.50"), so it accepts what String
+// prints, and may not have more decimals than the currency's minor unit.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	var currency Currency
+	var number string
+	if code, rest, ok := strings.Cut(s, " "); ok {
+		if c, err := LookupCurrency(code); err == nil {
+			currency, number = c, strings.TrimSpace(rest)
+		} else if c, err := LookupCurrency(strings.TrimSpace(rest)); err == nil {
+			currency, number = c, code
+		} else {
+			return Money{}, fmt.Errorf("parse money %q: %w", s, ErrUnknownCurrency)
+		}
+	} else {
+		for _, c := range currencies {
+			if c.Symbol == "" {
+				continue
+			}
+			if rest, ok := strings.CutPrefix(strings.TrimPrefix(s, "-"), c.Symbol); ok {
+				currency, number = c, rest
+				if strings.HasPrefix(s, "-") {
+					number = "-" + rest
+				}
+				break
+			}
+		}
+		if currency.Code == "" {
+			return Money{}, fmt.Errorf("parse money %q: no currency", s)
+		}
+	}
+
+	minor, err := parseMinor(number, currency.Digits)
+	if err != nil {
+		return Money{}, fmt.Errorf("parse money %q: %w", s, err)
+	}
+	return NewMoney(minor, currency), nil
+}
+
+// MustParseMoney is like ParseMoney but panics on error. It is meant for constants.
+func MustParseMoney(s string) Money {
+	m, err := ParseMoney(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// parseMinor converts a decimal string such as "-1,234.5" to minor units with digits
+// decimals. Thousands separators are optional, but must be in the right places.
+func parseMinor(number string, digits int) (int64, error) {
+	sign := ""
+	if rest, ok := strings.CutPrefix(number, "-"); ok {
+		sign, number = "-", rest
+	}
+	whole, frac, _ := strings.Cut(number, ".")
+	whole, ok := ungroupThousands(whole)
+	if !ok || whole == "" || strings.Trim(whole+frac, "0123456789") != "" {
+		return 0, fmt.Errorf("invalid amount %q", number)
+	}
+	if len(frac) > digits {
+		return 0, fmt.Errorf("amount %q has more than %d decimals", number, digits)
+	}
+	frac += strings.Repeat("0", digits-len(frac))
+	// The sign is parsed with the digits, so the most negative amount fits too.
+	minor, err := strconv.ParseInt(sign+whole+frac, 10, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, ErrOverflow
+		}
+		return 0, fmt.Errorf("invalid amount %q", number)
+	}
+	return minor, nil
+}
+
+// Minor returns the amount in minor units.
+func (m Money) Minor() int64 {
+	return m.amount
+}
+
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	sum := m.amount + other.amount
+	if (other.amount > 0 && sum < m.amount) || (other.amount < 0 && sum > m.amount) {
+		return Money{}, ErrOverflow
+	}
+	return NewMoney(sum, m.currency), nil
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	if other.amount == math.MinInt64 {
+		return Money{}, ErrOverflow
+	}
+	return m.Add(NewMoney(-other.amount, other.currency))
+}
+
+// Multiply returns m times n.
+func (m Money) Multiply(n int64) (Money, error) {
+	product := new(big.Int).Mul(big.NewInt(m.amount), big.NewInt(n))
+	if !product.IsInt64() {
+		return Money{}, ErrOverflow
+	}
+	return NewMoney(product.Int64(), m.currency), nil
+}
+
+// Cmp compares m and other: -1 if m is less, 0 if equal, +1 if greater.
+func (m Money) Cmp(other Money) (int, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.amount < other.amount:
+		return -1, nil
+	case m.amount > other.amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// Allocate splits m in proportion to ratios without losing a minor unit. The minor
+// units left over by rounding down go one each to the shares with the largest
+// remainders (the earliest share on ties), so Allocate(1, 1, 1) of $100.00 gives
+// $33.34, $33.33, $33.33.
+func (m Money) Allocate(ratios ...int) ([]Money, error) {
+	total := int64(0)
+	for _, r := range ratios {
+		if r < 0 {
+			return nil, fmt.Errorf("allocate: negative ratio %d", r)
+		}
+		total += int64(r)
+	}
+	if total == 0 {
+		return nil, errors.New("allocate: ratios must not all be zero")
+	}
+
+	amount := big.NewInt(m.amount)
+	negative := amount.Sign() < 0
+	amount.Abs(amount)
+
+	shares := make([]Money, len(ratios))
+	remainders := make([]*big.Int, len(ratios))
+	allocated := new(big.Int)
+	for i, r := range ratios {
+		share, rem := new(big.Int).QuoRem(new(big.Int).Mul(amount, big.NewInt(int64(r))), big.NewInt(total), new(big.Int))
+		shares[i] = NewMoney(share.Int64(), m.currency)
+		remainders[i] = rem
+		allocated.Add(allocated, share)
+	}
+
+	order := make([]int, len(ratios))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]].Cmp(remainders[order[b]]) > 0
+	})
+	left := new(big.Int).Sub(amount, allocated).Int64() // Fewer than len(ratios)
+	for _, i := range order[:left] {
+		shares[i].amount++
+	}
+
+	if negative {
+		for i := range shares {
+			shares[i].amount = -shares[i].amount
+		}
+	}
+	return shares, nil
+}
+
+// Split divides m into n shares that differ by at most one minor unit.
+func (m Money) Split(n int) ([]Money, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("split into %d shares", n)
+	}
+	ratios := make([]int, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return m.Allocate(ratios...)
+}
+
+// String formats m with its symbol ("$1,234.50") or, without one, its code ("CHF 1,234.50").
+func (m Money) String() string {
+	abs := m.amount
+	sign := ""
+	if abs < 0 {
+		sign = "-"
+	}
+	digits := strconv.FormatUint(absUint(abs), 10)
+	d := m.currency.Digits
+	if len(digits) <= d {
+		digits = strings.Repeat("0", d-len(digits)+1) + digits
+	}
+	whole, frac := digits[:len(digits)-d], digits[len(digits)-d:]
+	number := groupThousands(whole)
+	if d > 0 {
+		number += "." + frac
+	}
+	if m.currency.Symbol != "" {
+		return sign + m.currency.Symbol + number
+	}
+	return m.currency.Code + " " + sign + number
+}
+
+func (m Money) sameCurrency(other Money) error {
+	if m.currency.Code != other.currency.Code {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, other.currency)
+	}
+	return nil
+}
+
+func absUint(n int64) uint64 {
+	if n < 0 {
+		return uint64(-(n + 1)) + 1 // Also correct for math.MinInt64
+	}
+	return uint64(n)
+}
+
+// ungroupThousands removes the separators groupThousands inserts. It reports false
+// if they are misplaced, as in "12,34".
+func ungroupThousands(digits string) (string, bool) {
+	if !strings.Contains(digits, ",") {
+		return digits, true
+	}
+	groups := strings.Split(digits, ",")
+	if len(groups[0]) == 0 || len(groups[0]) > 3 {
+		return "", false
+	}
+	for _, g := range groups[1:] {
+		if len(g) != 3 {
+			return "", false
+		}
+	}
+	return strings.Join(groups, ""), true
+}
+
+func groupThousands(digits string) string {
+	var b strings.Builder
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
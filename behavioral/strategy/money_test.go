@@ -0,0 +1,136 @@
+package strategy
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMoneyStringRoundTrip(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{NewMoney(0, USD), "$0.00"},
+		{NewMoney(5, USD), "$0.05"},
+		{NewMoney(99_999, USD), "$999.99"},
+		{NewMoney(123_450, USD), "$1,234.50"},
+		{NewMoney(-123_450, USD), "-$1,234.50"},
+		{NewMoney(100_000_000, EUR), "€1,000,000.00"},
+		{NewMoney(123_456_789, CHF), "CHF 1,234,567.89"},
+		{NewMoney(-100_000, CHF), "CHF -1,000.00"},
+		{NewMoney(0, JPY), "¥0"},
+		{NewMoney(999, JPY), "¥999"},
+		{NewMoney(1_000, JPY), "¥1,000"},
+		{NewMoney(-1_234_567, JPY), "-¥1,234,567"},
+		{NewMoney(1, KWD), "KWD 0.001"},
+		{NewMoney(1_234_567_891, KWD), "KWD 1,234,567.891"},
+		{NewMoney(-1_000_000, KWD), "KWD -1,000.000"},
+		{NewMoney(math.MaxInt64, USD), "$92,233,720,368,547,758.07"},
+		{NewMoney(math.MinInt64, USD), "-$92,233,720,368,547,758.08"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.money.String(); got != tt.want {
+				t.Errorf("String = %q, want %q", got, tt.want)
+			}
+			parsed, err := ParseMoney(tt.money.String())
+			if err != nil {
+				t.Fatalf("ParseMoney(String()) = %v", err)
+			}
+			if parsed != tt.money {
+				t.Errorf("round trip = %v (%d minor units), want %d", parsed, parsed.Minor(), tt.money.Minor())
+			}
+		})
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Money
+		wantErr error // Checked with errors.Is when set
+		fails   bool
+	}{
+		{"USD 10.50", NewMoney(1050, USD), nil, false},
+		{"10.5 usd", NewMoney(1050, USD), nil, false},
+		{"$1234.5", NewMoney(123_450, USD), nil, false},
+		{"1,000 JPY", NewMoney(1000, JPY), nil, false},
+		{"KWD 12,345.6", NewMoney(12_345_600, KWD), nil, false},
+		{"-£0.01", NewMoney(-1, GBP), nil, false},
+		{"$12,34.00", Money{}, nil, true},
+		{"$1,2345.00", Money{}, nil, true},
+		{"$,123.00", Money{}, nil, true},
+		{"$1,234,", Money{}, nil, true},
+		{"0.005 USD", Money{}, nil, true},
+		{"1.5 JPY", Money{}, nil, true},
+		{"10.00", Money{}, nil, true},
+		{"10.00 XYZ", Money{}, ErrUnknownCurrency, true},
+		{"$92,233,720,368,547,758.08", Money{}, ErrOverflow, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.input)
+		switch {
+		case (err != nil) != tt.fails:
+			t.Errorf("ParseMoney(%q) error = %v, want failure %t", tt.input, err, tt.fails)
+		case tt.wantErr != nil && !errors.Is(err, tt.wantErr):
+			t.Errorf("ParseMoney(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+		case got != tt.want:
+			t.Errorf("ParseMoney(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	ten, three := NewMoney(1000, USD), NewMoney(300, USD)
+	if sum, err := ten.Add(three); err != nil || sum != NewMoney(1300, USD) {
+		t.Errorf("Add = %v, %v", sum, err)
+	}
+	if diff, err := three.Sub(ten); err != nil || diff != NewMoney(-700, USD) {
+		t.Errorf("Sub = %v, %v", diff, err)
+	}
+	if _, err := ten.Add(NewMoney(1, EUR)); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Add across currencies: %v, want ErrCurrencyMismatch", err)
+	}
+	if _, err := NewMoney(math.MaxInt64, USD).Add(NewMoney(1, USD)); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Add past MaxInt64: %v, want ErrOverflow", err)
+	}
+	if _, err := NewMoney(1, USD).Sub(NewMoney(math.MinInt64, USD)); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Sub of MinInt64: %v, want ErrOverflow", err)
+	}
+	if _, err := NewMoney(math.MaxInt64/2+1, USD).Multiply(2); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Multiply overflow: %v, want ErrOverflow", err)
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		money  Money
+		ratios []int
+		want   []int64
+	}{
+		{NewMoney(10_000, USD), []int{1, 1, 1}, []int64{3334, 3333, 3333}},
+		{NewMoney(-10_000, USD), []int{1, 1, 1}, []int64{-3334, -3333, -3333}},
+		{NewMoney(1001, JPY), []int{70, 30}, []int64{701, 300}},
+		{NewMoney(5, USD), []int{1, 0, 1}, []int64{3, 0, 2}},
+		{NewMoney(100, USD), []int{1, 2, 3, 4}, []int64{10, 20, 30, 40}},
+	}
+	for _, tt := range tests {
+		shares, err := tt.money.Allocate(tt.ratios...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, share := range shares {
+			if share.Minor() != tt.want[i] || share.Currency() != tt.money.Currency() {
+				t.Errorf("%v.Allocate(%v) = %v, want minor units %v", tt.money, tt.ratios, shares, tt.want)
+				break
+			}
+		}
+	}
+	if _, err := NewMoney(100, USD).Allocate(0, 0); err == nil {
+		t.Error("Allocate with all-zero ratios succeeded")
+	}
+	if _, err := NewMoney(100, USD).Split(0); err == nil {
+		t.Error("Split into zero shares succeeded")
+	}
+}
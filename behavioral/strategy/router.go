@@ -0,0 +1,257 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// --- Payment routing ---
+// A PaymentRouter is itself a PaymentStrategy. For every payment it picks the first
+// RoutingRule with candidates that matches the amount, currency and customer tier (rules
+// without candidates are skipped), then tries the rule's
+// candidates in order. It moves on to the next candidate only after a retryable
+// decline; a hard decline or an unexpected error ends the payment, since trying
+// another method could charge the customer twice.
+
+// DeclineError is returned by a strategy that refused a payment.
+type DeclineError struct {
+	Method    string // Payment method that declined, e.g. "credit card"
+	Reason    string
+	Retryable bool // True if another payment method may succeed
+}
+
+func (e *DeclineError) Error() string {
+	return e.Method + " payment " + e.Reason
+}
+
+func softDecline(method, format string, args ...any) *DeclineError {
+	return &DeclineError{Method: method, Reason: fmt.Sprintf(format, args...), Retryable: true}
+}
+
+func hardDecline(method, format string, args ...any) *DeclineError {
+	return &DeclineError{Method: method, Reason: fmt.Sprintf(format, args...)}
+}
+
+// IsRetryable reports whether err is a decline after which another payment method may
+// be tried. Errors that are not a DeclineError are not retryable: their outcome is unknown.
+// A *RoutingError is retryable if its last attempt was, whatever the earlier ones were.
+func IsRetryable(err error) bool {
+	var routing *RoutingError
+	if errors.As(err, &routing) {
+		return routing.Retryable()
+	}
+	var decline *DeclineError
+	return errors.As(err, &decline) && decline.Retryable
+}
+
+// ErrNoRoute is returned when no routing rule matches a payment.
+var ErrNoRoute = errors.New("no payment route")
+
+// CustomerTier is a customer's loyalty tier, used to pick payment routes.
+type CustomerTier int
+
+const (
+	Standard CustomerTier = iota
+	Gold
+	Platinum
+)
+
+func (t CustomerTier) String() string {
+	switch t {
+	case Standard:
+		return "standard"
+	case Gold:
+		return "gold"
+	case Platinum:
+		return "platinum"
+	default:
+		return "tier(" + strconv.Itoa(int(t)) + ")"
+	}
+}
+
+// Candidate is a named payment strategy a rule may route to.
+type Candidate struct {
+	Name     string
+	Strategy PaymentStrategy
+}
+
+// RoutingRule selects candidates for payments it matches. Unset fields match everything.
+type RoutingRule struct {
+	Name       string
+	Min, Max   Money          // Inclusive bounds; a Money without currency leaves that end open
+	Currencies []Currency     // Empty matches every currency
+	Tiers      []CustomerTier // Empty matches every tier
+	Candidates []Candidate    // Tried in order; a rule without any never matches
+}
+
+func (rule RoutingRule) matches(amount Money, tier CustomerTier) bool {
+	if len(rule.Currencies) > 0 && !slices.ContainsFunc(rule.Currencies, func(c Currency) bool {
+		return amount.sameCurrency(NewMoney(0, c)) == nil
+	}) {
+		return false
+	}
+	if len(rule.Tiers) > 0 && !slices.Contains(rule.Tiers, tier) {
+		return false
+	}
+	if rule.Min.Currency().Code != "" {
+		if c, err := amount.Cmp(rule.Min); err != nil || c < 0 {
+			return false
+		}
+	}
+	if rule.Max.Currency().Code != "" {
+		if c, err := amount.Cmp(rule.Max); err != nil || c > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Attempt records one candidate tried for a payment.
+type Attempt struct {
+	Candidate string
+	Err       error // Nil if the candidate took the payment
+	Duration  time.Duration
+}
+
+// RoutingReport describes how a payment was routed.
+type RoutingReport struct {
+	Amount   Money
+	Tier     CustomerTier
+	Matched  bool   // False if no rule with candidates matched
+	Rule     string // Name of the matching rule, which may be empty
+	Attempts []Attempt
+	PaidWith string // Candidate that took the payment, empty if none did
+}
+
+func (r RoutingReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "payment of %v for %s customer", r.Amount, r.Tier)
+	if !r.Matched {
+		b.WriteString(": no matching rule")
+		return b.String()
+	}
+	fmt.Fprintf(&b, " via rule %q", r.Rule)
+	for i, a := range r.Attempts {
+		fmt.Fprintf(&b, "\n  %d. %s: ", i+1, a.Candidate)
+		var decline *DeclineError
+		switch {
+		case a.Err == nil:
+			b.WriteString("paid")
+		case !errors.As(a.Err, &decline):
+			fmt.Fprintf(&b, "failed (%v)", a.Err)
+		case decline.Retryable:
+			fmt.Fprintf(&b, "declined, retryable (%v)", a.Err)
+		default:
+			fmt.Fprintf(&b, "hard decline (%v)", a.Err)
+		}
+	}
+	return b.String()
+}
+
+// RoutingError is returned when a routed payment was not taken. Its attempt errors
+// can be inspected with errors.As.
+type RoutingError struct {
+	Report RoutingReport
+}
+
+func (e *RoutingError) Error() string {
+	if !e.Report.Matched {
+		return fmt.Sprintf("%v: %v for %s customer", ErrNoRoute, e.Report.Amount, e.Report.Tier)
+	}
+	last := e.Report.Attempts[len(e.Report.Attempts)-1]
+	return fmt.Sprintf("payment of %v failed after %d attempt(s) via rule %q: %v",
+		e.Report.Amount, len(e.Report.Attempts), e.Report.Rule, last.Err)
+}
+
+func (e *RoutingError) Unwrap() []error {
+	if !e.Report.Matched {
+		return []error{ErrNoRoute}
+	}
+	errs := make([]error, 0, len(e.Report.Attempts))
+	for _, a := range e.Report.Attempts {
+		errs = append(errs, a.Err)
+	}
+	return errs
+}
+
+// Retryable reports whether the last attempt ended in a retryable decline, so that a
+// router using this one as a candidate may go on to its next candidate. Earlier
+// attempts do not count: the last one is why the payment stopped.
+func (e *RoutingError) Retryable() bool {
+	if !e.Report.Matched {
+		return false
+	}
+	return IsRetryable(e.Report.Attempts[len(e.Report.Attempts)-1].Err)
+}
+
+// PaymentRouter implements PaymentStrategy by delegating to the candidates of the
+// first matching rule.
+type PaymentRouter struct {
+	rules []RoutingRule
+	tier  CustomerTier
+
+	mu   sync.Mutex
+	last RoutingReport
+}
+
+// NewPaymentRouter routes payments of Standard customers; see ForCustomer.
+func NewPaymentRouter(rules ...RoutingRule) *PaymentRouter {
+	return &PaymentRouter{rules: rules}
+}
+
+// ForCustomer returns a router with the same rules for customers of tier.
+func (r *PaymentRouter) ForCustomer(tier CustomerTier) *PaymentRouter {
+	return &PaymentRouter{rules: r.rules, tier: tier}
+}
+
+func (r *PaymentRouter) Pay(amount Money) error {
+	_, err := r.Route(amount)
+	return err
+}
+
+// Route pays amount and reports every attempt. The error is a *RoutingError unless
+// the payment was taken.
+func (r *PaymentRouter) Route(amount Money) (RoutingReport, error) {
+	report := RoutingReport{Amount: amount, Tier: r.tier}
+	defer func() {
+		r.mu.Lock()
+		r.last = report
+		r.mu.Unlock()
+	}()
+
+	i := slices.IndexFunc(r.rules, func(rule RoutingRule) bool {
+		return len(rule.Candidates) > 0 && rule.matches(amount, r.tier)
+	})
+	if i < 0 {
+		return report, &RoutingError{Report: report}
+	}
+	rule := r.rules[i]
+	report.Matched, report.Rule = true, rule.Name
+	for _, c := range rule.Candidates {
+		start := time.Now()
+		err := c.Strategy.Pay(amount)
+		report.Attempts = append(report.Attempts, Attempt{Candidate: c.Name, Err: err, Duration: time.Since(start)})
+		if err == nil {
+			report.PaidWith = c.Name
+			return report, nil
+		}
+		if !IsRetryable(err) {
+			break
+		}
+	}
+	return report, &RoutingError{Report: report}
+}
+
+// LastReport returns the report of the most recent payment. It is meant for demos
+// and single-threaded callers: with concurrent payments it holds whichever finished
+// last, so use the report returned by Route instead.
+func (r *PaymentRouter) LastReport() RoutingReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.last
+}
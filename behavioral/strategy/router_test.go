@@ -0,0 +1,137 @@
+package strategy
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// payFunc adapts a function to PaymentStrategy.
+type payFunc func(Money) error
+
+func (f payFunc) Pay(amount Money) error { return f(amount) }
+
+var (
+	pays = payFunc(func(Money) error { return nil })
+	soft = payFunc(func(Money) error { return softDecline("soft", "declined") })
+	hard = payFunc(func(Money) error { return hardDecline("hard", "declined") })
+	odd  = payFunc(func(Money) error { return errors.New("gateway timeout") })
+)
+
+func TestPaymentRouter(t *testing.T) {
+	nested := func(candidates ...Candidate) PaymentStrategy {
+		return NewPaymentRouter(RoutingRule{Name: "inner", Candidates: candidates})
+	}
+	tests := []struct {
+		name         string
+		rules        []RoutingRule
+		tier         CustomerTier
+		amount       Money
+		wantMatched  bool
+		wantRule     string
+		wantAttempts []string
+		wantPaidWith string
+		wantNoRoute  bool
+		wantRetry    bool
+	}{
+		{"first candidate pays",
+			[]RoutingRule{{Name: "all", Candidates: []Candidate{{"a", pays}, {"b", pays}}}},
+			Standard, NewMoney(100, USD), true, "all", []string{"a"}, "a", false, false},
+		{"soft decline falls back",
+			[]RoutingRule{{Name: "all", Candidates: []Candidate{{"a", soft}, {"b", pays}}}},
+			Standard, NewMoney(100, USD), true, "all", []string{"a", "b"}, "b", false, false},
+		{"hard decline stops",
+			[]RoutingRule{{Name: "all", Candidates: []Candidate{{"a", hard}, {"b", pays}}}},
+			Standard, NewMoney(100, USD), true, "all", []string{"a"}, "", false, false},
+		{"unknown error stops",
+			[]RoutingRule{{Name: "all", Candidates: []Candidate{{"a", odd}, {"b", pays}}}},
+			Standard, NewMoney(100, USD), true, "all", []string{"a"}, "", false, false},
+		{"unnamed rule keeps its attempts",
+			[]RoutingRule{{Candidates: []Candidate{{"a", soft}, {"b", soft}}}},
+			Standard, NewMoney(100, USD), true, "", []string{"a", "b"}, "", false, true},
+		{"no rule matches",
+			[]RoutingRule{{Name: "euro", Currencies: []Currency{EUR}, Candidates: []Candidate{{"a", pays}}}},
+			Standard, NewMoney(100, USD), false, "", nil, "", true, false},
+		{"rule without candidates is skipped",
+			[]RoutingRule{{Name: "empty"}, {Name: "all", Candidates: []Candidate{{"a", pays}}}},
+			Standard, NewMoney(100, USD), true, "all", []string{"a"}, "a", false, false},
+		{"only rules without candidates",
+			[]RoutingRule{{Name: "empty"}, {Name: "usd", Currencies: []Currency{USD}}},
+			Standard, NewMoney(100, USD), false, "", nil, "", true, false},
+		{"currency matched by code",
+			[]RoutingRule{{Name: "usd", Currencies: []Currency{{Code: "USD", Digits: 2}}, Candidates: []Candidate{{"a", pays}}}},
+			Standard, NewMoney(100, USD), true, "usd", []string{"a"}, "a", false, false},
+		{"tier and bounds pick the rule",
+			[]RoutingRule{
+				{Name: "gold", Tiers: []CustomerTier{Gold}, Candidates: []Candidate{{"g", pays}}},
+				{Name: "small", Max: NewMoney(99, USD), Candidates: []Candidate{{"s", pays}}},
+				{Name: "large", Min: NewMoney(100, USD), Max: NewMoney(1000, USD), Candidates: []Candidate{{"l", pays}}},
+			},
+			Standard, NewMoney(1000, USD), true, "large", []string{"l"}, "l", false, false},
+		{"bounds in another currency do not match",
+			[]RoutingRule{{Name: "large", Min: NewMoney(100, USD), Candidates: []Candidate{{"l", pays}}}},
+			Standard, NewMoney(1000, EUR), false, "", nil, "", true, false},
+		{"nested soft then hard is not retried",
+			[]RoutingRule{{Name: "outer", Candidates: []Candidate{
+				{"inner", nested(Candidate{"a", soft}, Candidate{"b", hard})}, {"fallback", pays}}}},
+			Standard, NewMoney(100, USD), true, "outer", []string{"inner"}, "", false, false},
+		{"nested soft declines fall back",
+			[]RoutingRule{{Name: "outer", Candidates: []Candidate{
+				{"inner", nested(Candidate{"a", soft}, Candidate{"b", soft})}, {"fallback", pays}}}},
+			Standard, NewMoney(100, USD), true, "outer", []string{"inner", "fallback"}, "fallback", false, false},
+		{"nested no route is not retried",
+			[]RoutingRule{{Name: "outer", Candidates: []Candidate{
+				{"inner", NewPaymentRouter(RoutingRule{Currencies: []Currency{EUR}, Candidates: []Candidate{{"a", pays}}})},
+				{"fallback", pays}}}},
+			Standard, NewMoney(100, USD), true, "outer", []string{"inner"}, "", true, false}, // The inner ErrNoRoute shows through
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewPaymentRouter(tt.rules...).ForCustomer(tt.tier)
+			report, err := router.Route(tt.amount)
+			var names []string
+			for _, a := range report.Attempts {
+				names = append(names, a.Candidate)
+			}
+			if report.Matched != tt.wantMatched || report.Rule != tt.wantRule || report.PaidWith != tt.wantPaidWith ||
+				!slices.Equal(names, tt.wantAttempts) {
+				t.Errorf("report = %+v, want matched %t, rule %q, attempts %v, paid with %q",
+					report, tt.wantMatched, tt.wantRule, tt.wantAttempts, tt.wantPaidWith)
+			}
+			if (err == nil) != (tt.wantPaidWith != "") {
+				t.Fatalf("Route error = %v, want success %t", err, tt.wantPaidWith != "")
+			}
+			if err == nil {
+				return
+			}
+			var routing *RoutingError
+			if !errors.As(err, &routing) {
+				t.Fatalf("Route error %v is not a *RoutingError", err)
+			}
+			if got := errors.Is(err, ErrNoRoute); got != tt.wantNoRoute {
+				t.Errorf("errors.Is(err, ErrNoRoute) = %t, want %t (%v)", got, tt.wantNoRoute, err)
+			}
+			if got := IsRetryable(err); got != tt.wantRetry {
+				t.Errorf("IsRetryable = %t, want %t (%v)", got, tt.wantRetry, err)
+			}
+			if got := router.LastReport(); got.Rule != report.Rule || len(got.Attempts) != len(report.Attempts) {
+				t.Errorf("LastReport = %+v, want the report Route returned", got)
+			}
+		})
+	}
+}
+
+func TestRoutingErrorExposesAttempts(t *testing.T) {
+	router := NewPaymentRouter(RoutingRule{Candidates: []Candidate{{"a", soft}, {"b", hard}}})
+	err := router.Pay(NewMoney(100, USD))
+	var decline *DeclineError
+	if !errors.As(err, &decline) || decline.Method != "soft" {
+		t.Errorf("errors.As found %+v, want the first attempt's decline", decline)
+	}
+	if IsRetryable(err) {
+		t.Error("a route ending in a hard decline is retryable")
+	}
+	if want := `payment of $1.00 failed after 2 attempt(s) via rule "": hard payment declined`; err.Error() != want {
+		t.Errorf("Error = %q, want %q", err, want)
+	}
+}
@@ -0,0 +1,142 @@
+package strategy
+
+import (
+	"fmt"
+)
+
+// --- 1. Strategy (Interface) ---
+// Defines the common interface for all payment methods.
+type PaymentStrategy interface {
+	Pay(amount Money) error
+}
+
+// --- 2. Concrete Strategy(s) ---
+// Strategies refuse payments with a *DeclineError, retryable unless the payment
+// method itself is unusable.
+
+// Simulated limits of the payment providers, keyed by currency code. Payments in a
+// currency without an entry are not limited.
+var (
+	creditCardLimits = limits(NewMoney(1000_00, USD), NewMoney(1000_00, EUR), NewMoney(800_00, GBP), NewMoney(150_000, JPY))
+	payPalLimits     = limits(NewMoney(500_00, USD), NewMoney(500_00, EUR), NewMoney(400_00, GBP), NewMoney(75_000, JPY))
+)
+
+func limits(amounts ...Money) map[string]Money {
+	m := make(map[string]Money, len(amounts))
+	for _, a := range amounts {
+		m[a.Currency().Code] = a
+	}
+	return m
+}
+
+// exceeds reports whether amount is over the limit for its currency.
+func exceeds(amount Money, limits map[string]Money) bool {
+	limit, ok := limits[amount.Currency().Code]
+	if !ok {
+		return false
+	}
+	over, _ := amount.Cmp(limit) // Same currency by construction
+	return over > 0
+}
+
+// CreditCardPayment is a concrete strategy for credit card payments.
+type CreditCardPayment struct {
+	cardNumber string
+	cvv        string
+}
+
+func NewCreditCardPayment(cardNumber, cvv string) *CreditCardPayment {
+	return &CreditCardPayment{cardNumber: cardNumber, cvv: cvv}
+}
+
+func (c *CreditCardPayment) Pay(amount Money) error {
+	fmt.Printf("Processing credit card payment of %v using card %s...\n", amount, c.cardNumber)
+	// Simulate actual credit card processing logic
+	if exceeds(amount, creditCardLimits) { // Simulate a large transaction failure
+		return softDecline("credit card", "declined for amount %v", amount)
+	}
+	fmt.Println("Credit card payment successful!")
+	return nil
+}
+
+// PayPalPayment is a concrete strategy for PayPal payments.
+type PayPalPayment struct {
+	email string
+}
+
+func NewPayPalPayment(email string) *PayPalPayment {
+	return &PayPalPayment{email: email}
+}
+
+func (p *PayPalPayment) Pay(amount Money) error {
+	fmt.Printf("Processing PayPal payment of %v for account %s...\n", amount, p.email)
+	// Simulate actual PayPal API interaction
+	if exceeds(amount, payPalLimits) { // Simulate a PayPal limit
+		return softDecline("PayPal", "limit exceeded for amount %v", amount)
+	}
+	fmt.Println("PayPal payment successful!")
+	return nil
+}
+
+// CryptocurrencyPayment is a concrete strategy for cryptocurrency payments.
+type CryptocurrencyPayment struct {
+	walletAddress string
+	cryptoType    string
+}
+
+func NewCryptocurrencyPayment(walletAddress, cryptoType string) *CryptocurrencyPayment {
+	return &CryptocurrencyPayment{walletAddress: walletAddress, cryptoType: cryptoType}
+}
+
+func (c *CryptocurrencyPayment) Pay(amount Money) error {
+	fmt.Printf("Processing %v in %s to wallet %s...\n", amount, c.cryptoType, c.walletAddress)
+	// Simulate blockchain transaction
+	// Simulate minimum transaction amount: one minor unit, such as a cent, of any currency
+	if under, _ := amount.Cmp(NewMoney(1, amount.Currency())); under < 0 {
+		return softDecline(c.cryptoType, "minimum not met for amount %v", amount)
+	}
+	fmt.Println("Cryptocurrency payment successful!")
+	return nil
+}
+
+// --- 3. Context ---
+// The ShoppingCart uses a PaymentStrategy to process payments.
+type ShoppingCart struct {
+	amount Money
+	// Context holds a reference to the strategy interface.
+	paymentStrategy PaymentStrategy
+}
+
+func NewShoppingCart(amount Money) *ShoppingCart {
+	return &ShoppingCart{amount: amount}
+}
+
+// AddItem adds price to the cart total. It fails if price is in another currency.
+func (sc *ShoppingCart) AddItem(price Money) error {
+	total, err := sc.amount.Add(price)
+	if err != nil {
+		return err
+	}
+	sc.amount = total
+	return nil
+}
+
+func (sc *ShoppingCart) Total() Money {
+	return sc.amount
+}
+
+// SetPaymentStrategy allows the client to choose the strategy at runtime.
+func (sc *ShoppingCart) SetPaymentStrategy(strategy PaymentStrategy) {
+	sc.paymentStrategy = strategy
+	fmt.Printf("ShoppingCart: Payment strategy set.\n")
+}
+
+// Checkout delegates the payment task to the current strategy.
+func (sc *ShoppingCart) Checkout() error {
+	if sc.paymentStrategy == nil {
+		return fmt.Errorf("no payment strategy set")
+	}
+	fmt.Printf("ShoppingCart: Initiating checkout for total %v...\n", sc.amount)
+	// The Context delegates to the strategy
+	return sc.paymentStrategy.Pay(sc.amount)
+}
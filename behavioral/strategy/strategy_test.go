@@ -0,0 +1,71 @@
+package strategy
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStrategyLimits(t *testing.T) {
+	card := NewCreditCardPayment("1234-5678-9012-3456", "123")
+	payPal := NewPayPalPayment("user@example.com")
+	crypto := NewCryptocurrencyPayment("0xAbc123", "ETH")
+	tests := []struct {
+		name      string
+		strategy  PaymentStrategy
+		amount    string
+		wantOK    bool
+		retryable bool
+	}{
+		{"card at its limit", card, "$1,000.00", true, false},
+		{"card over its limit", card, "$1,000.01", false, true},
+		{"card in euros", card, "€999.00", true, false},
+		{"card over its yen limit", card, "150001 JPY", false, true},
+		{"card in a currency without a limit", card, "CHF 5,000.00", true, false},
+		{"PayPal under its limit", payPal, "$500.00", true, false},
+		{"PayPal over its limit", payPal, "$500.01", false, true},
+		{"PayPal over its pound limit", payPal, "£400.01", false, true},
+		{"PayPal in dinars", payPal, "KWD 900.000", true, false},
+		{"crypto one cent", crypto, "$0.01", true, false},
+		{"crypto nothing", crypto, "$0.00", false, true},
+		{"crypto one yen", crypto, "1 JPY", true, false},
+		{"crypto one fils", crypto, "KWD 0.001", true, false},
+		{"crypto refund", crypto, "CHF -1.00", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.strategy.Pay(MustParseMoney(tt.amount))
+			if (err == nil) != tt.wantOK {
+				t.Fatalf("Pay(%s) = %v, want success %t", tt.amount, err, tt.wantOK)
+			}
+			if err != nil && IsRetryable(err) != tt.retryable {
+				t.Errorf("IsRetryable(%v) = %t, want %t", err, IsRetryable(err), tt.retryable)
+			}
+			var decline *DeclineError
+			if err != nil && !errors.As(err, &decline) {
+				t.Errorf("Pay error %v is not a *DeclineError", err)
+			}
+		})
+	}
+}
+
+func TestShoppingCart(t *testing.T) {
+	cart := NewShoppingCart(NewMoney(0, USD))
+	if err := cart.Checkout(); err == nil {
+		t.Error("Checkout without a strategy succeeded")
+	}
+	for _, price := range []string{"$0.10", "$0.20", "$999.70"} {
+		if err := cart.AddItem(MustParseMoney(price)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := cart.AddItem(MustParseMoney("€1.00")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("adding euros to a dollar cart: %v, want ErrCurrencyMismatch", err)
+	}
+	if total := cart.Total(); total != NewMoney(1000_00, USD) {
+		t.Errorf("Total = %v, want $1,000.00", total)
+	}
+	cart.SetPaymentStrategy(NewCreditCardPayment("1234", "123"))
+	if err := cart.Checkout(); err != nil {
+		t.Errorf("Checkout at the card limit: %v", err)
+	}
+}
@@ -1,4 +1,4 @@
-package main
+package templatemethod
 
 import "fmt"
 
@@ -75,18 +75,3 @@ func (bb *BrickHouseBuilder) AddRoof() {
 func (bb *BrickHouseBuilder) InstallFixtures() {
 	fmt.Println("  BrickHouseBuilder: Installing premium marble fixtures.") // Different implementation
 }
-
-// --- Client Code ---
-func main() {
-	// Build a Wooden House
-	fmt.Println("Client: Requesting a Wooden House.")
-	woodenBuilder := &WoodenHouseBuilder{}
-	woodenHouseProcess := NewConstructionProcess(woodenBuilder) // Client provides the specific builder
-	woodenHouseProcess.BuildHouse()
-
-	// Build a Brick House
-	fmt.Println("\nClient: Requesting a Brick House.")
-	brickBuilder := &BrickHouseBuilder{}
-	brickHouseProcess := NewConstructionProcess(brickBuilder) // Client provides another specific builder
-	brickHouseProcess.BuildHouse()
-}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hardworking-gopher/GoF/creational/abstractfactory"
+)
+
+func main() {
+	adidasFactory, _ := abstractfactory.GetSportsFactory("adidas")
+	nikeFactory, _ := abstractfactory.GetSportsFactory("nike")
+
+	nikeShirt := nikeFactory.MakeShirt()
+	nikeShoe := nikeFactory.MakeShoe()
+
+	adidasShirt := adidasFactory.MakeShirt()
+	adidasShoe := adidasFactory.MakeShoe()
+
+	printShirtDetails(nikeShirt)
+	printShoeDetails(nikeShoe)
+
+	printShirtDetails(adidasShirt)
+	printShoeDetails(adidasShoe)
+}
+
+func printShirtDetails(s abstractfactory.IShirt) {
+	fmt.Printf("Logo: %s, Size: %d\n", s.GetLogo(), s.GetSize())
+}
+
+func printShoeDetails(s abstractfactory.IShoe) {
+	fmt.Printf("Logo: %s, Size: %d\n", s.GetLogo(), s.GetSize())
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hardworking-gopher/GoF/structural/adapter"
+)
+
+// --- Main function to demonstrate usage ---
+func main() {
+	// Scenario 1: Directly using the Adaptee (not compatible with ClientCode)
+	// legacyConverter := &LegacyDataConverter{}
+	// This would not compile: ClientCode(legacyConverter, "test") because LegacyDataConverter does not implement DataProcessor
+
+	// Scenario 2: Using the Adapter to make the Adaptee compatible
+	fmt.Println("--- Using the Adapter ---")
+	legacyConverter := &adapter.LegacyDataConverter{}
+	legacyAdapter := adapter.NewLegacyDataConverterAdapter(legacyConverter)
+
+	// Now, the client code can use the adapter seamlessly
+	adapter.ClientCode(legacyAdapter, "hello_world")
+
+	fmt.Println("\n--- Another Use Case ---")
+	adapter.ClientCode(legacyAdapter, "sample_data")
+}
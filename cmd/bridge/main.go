@@ -1,12 +1,16 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/hardworking-gopher/GoF/structural/bridge"
+)
 
 func main() {
-	hpPrinter := &Hp{}
-	epsonPrinter := &Epson{}
+	hpPrinter := &bridge.Hp{}
+	epsonPrinter := &bridge.Epson{}
 
-	macComputer := &Mac{}
+	macComputer := &bridge.Mac{}
 
 	macComputer.SetPrinter(hpPrinter)
 	macComputer.Print()
@@ -16,7 +20,7 @@ func main() {
 	macComputer.Print()
 	fmt.Println()
 
-	winComputer := &Windows{}
+	winComputer := &bridge.Windows{}
 
 	winComputer.SetPrinter(hpPrinter)
 	winComputer.Print()
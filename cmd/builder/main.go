@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hardworking-gopher/GoF/creational/builder"
+)
+
+// --- Main function to demonstrate usage ---
+func main() {
+	test := builder.NewPizzaBuilder().Build()
+	fmt.Println("--- Test pizza  ---")
+	fmt.Println(test)
+
+	// Build a simple pizza
+	fmt.Println("\n--- Meat Lovers Pizza ---")
+	// Build a meat lovers pizza
+	fmt.Println("\n--- Veggie Delight (No Cheese) ---")
+	// Build a veggie pizza without cheese
+	veggieNoCheese := builder.NewPizzaBuilder().
+		WithSize("Medium").
+		WithCrust("Whole Wheat").
+		WithSauce("Pesto").
+		AddTopping("Mushrooms").
+		AddTopping("Onions").
+		AddTopping("Bell Peppers").
+		WithCheese(false). // Explicitly no cheese
+		Build()
+	fmt.Println(veggieNoCheese)
+
+	fmt.Println("\n--- Small Default Pizza (Minimal Configuration) ---")
+	// Build a pizza using mostly defaults, just changing size
+	smallDefault := builder.NewPizzaBuilder().
+		WithSize("Small").
+		Build()
+	fmt.Println(smallDefault)
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hardworking-gopher/GoF/behavioral/command"
+)
+
+// --- Client Code ---
+func main() {
+	// The Receiver: The actual light bulb.
+	livingRoomLight := &command.Light{}
+
+	// The Concrete Commands: Created by the client, linking a specific action to a specific receiver.
+	turnOnLight := command.NewTurnOnCommand(livingRoomLight)
+	turnOffLight := command.NewTurnOffCommand(livingRoomLight)
+
+	// The Invoker: The remote control.
+	remote := command.NewRemoteControl(command.DefaultHistoryLimit)
+
+	// Client configures the remote control (Invoker) with different commands.
+	// The remote (Invoker) doesn't know what a "Light" is or how to "TurnOn/Off".
+	// It just knows how to "Execute" a Command.
+
+	remote.SetCommand(turnOnLight)
+	remote.PressButton() // Light is ON
+
+	remote.SetCommand(turnOffLight)
+	remote.PressButton() // Light is OFF
+
+	// You could even set the same command multiple times
+	remote.SetCommand(turnOnLight)
+	remote.PressButton() // Light is ON
+	remote.PressButton() // Light is ON (pressed twice)
+
+	// Undo walks back through the history, restoring the previous state each time.
+	remote.PressUndo() // Light is ON (it was already on before the second press)
+	remote.PressUndo() // Light is OFF
+	remote.PressRedo() // Light is ON
+
+	// Executing a new command after an undo clears the redo stack.
+	remote.PressUndo() // Light is OFF
+	remote.SetCommand(turnOffLight)
+	remote.PressButton() // Light is OFF
+	remote.PressRedo()   // Nothing to redo
+
+	programmableRemoteDemo()
+	journalDemo()
+	queueDemo()
+	schedulerDemo()
+	transactionDemo()
+	httpDemo()
+}
+
+func programmableRemoteDemo() {
+	fmt.Println("\n--- Programmable remote with a JSON layout ---")
+
+	// The registry maps the names used in the layout file to live receivers.
+	registry := command.NewRegistry()
+	registry.RegisterReceiver("living", &command.Light{})
+	registry.RegisterReceiver("kitchen", &command.Light{})
+
+	layout := command.Layout{Slots: []command.SlotSpec{
+		{
+			Name: "living",
+			On:   &command.CommandSpec{Kind: "light.on", Receiver: "living"},
+			Off:  &command.CommandSpec{Kind: "light.off", Receiver: "living"},
+		},
+		{
+			Name: "party",
+			On: &command.CommandSpec{Kind: command.MacroKind, Commands: []command.CommandSpec{
+				{Kind: "light.on", Receiver: "living"},
+				{Kind: "light.on", Receiver: "kitchen"},
+			}},
+		},
+	}}
+
+	dir, err := os.MkdirTemp("", "remote-layout")
+	if err != nil {
+		fmt.Printf("Could not create layout dir: %v\n", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	// Round-trip the layout through a file, as a deployed remote would.
+	path := filepath.Join(dir, "layout.json")
+	if err := command.SaveLayout(path, layout); err != nil {
+		fmt.Printf("Could not save layout: %v\n", err)
+		return
+	}
+	loaded, err := command.LoadLayout(path)
+	if err != nil {
+		fmt.Printf("Could not load layout: %v\n", err)
+		return
+	}
+
+	remote := command.NewProgrammableRemote(4, command.DefaultHistoryLimit)
+	if err := remote.ApplyLayout(loaded, registry); err != nil {
+		fmt.Printf("Could not apply layout: %v\n", err)
+		return
+	}
+	fmt.Printf("Slots: %v\n", remote.Slots())
+
+	remote.PressOn("party")  // Both lights ON
+	remote.PressOff("party") // No command set
+	remote.PressUndo()       // Both lights back OFF, in reverse order
+}
+
+func journalDemo() {
+	fmt.Println("\n--- Replaying a command journal after a crash ---")
+
+	dir, err := os.MkdirTemp("", "remote-journal")
+	if err != nil {
+		fmt.Printf("Could not create journal dir: %v\n", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "commands.journal")
+
+	// First run: every executed command is appended to the journal.
+	porch := &command.Light{}
+	registry := command.NewRegistry()
+	registry.RegisterReceiver("porch", porch)
+	journal, err := command.OpenJournal(path, registry)
+	if err != nil {
+		fmt.Printf("Could not open journal: %v\n", err)
+		return
+	}
+	journal.Execute(command.CommandSpec{Kind: "light.on", Receiver: "porch"})
+	journal.Execute(command.CommandSpec{Kind: "light.off", Receiver: "porch"})
+	journal.Execute(command.CommandSpec{Kind: "light.on", Receiver: "porch"})
+	journal.Close()
+
+	// Simulate a crash in the middle of the next write: a torn record at the tail.
+	if file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0); err == nil {
+		file.Write([]byte{0, 0, 0, 42, 1, 2})
+		file.Close()
+	}
+
+	// Second run: a fresh receiver is rebuilt from the journal, the torn tail is dropped.
+	freshPorch := &command.Light{}
+	registry = command.NewRegistry()
+	registry.RegisterReceiver("porch", freshPorch)
+	journal, err = command.OpenJournal(path, registry)
+	if err != nil {
+		fmt.Printf("Could not replay journal: %v\n", err)
+		return
+	}
+	defer journal.Close()
+	fmt.Printf("Replayed %d commands, porch light on: %t\n", journal.LastSeq(), freshPorch.IsOn())
+}
+
+// flakyCommand fails a fixed number of times before it succeeds, like a device
+// that needs a moment to wake up.
+type flakyCommand struct {
+	name     string
+	failures int
+}
+
+func (c *flakyCommand) Execute() error {
+	if c.failures > 0 {
+		c.failures--
+		fmt.Printf("%s: device not ready\n", c.name)
+		return errors.New("device not ready")
+	}
+	fmt.Printf("%s: done\n", c.name)
+	return nil
+}
+
+func (c *flakyCommand) Undo() error { return nil }
+
+func queueDemo() {
+	fmt.Println("\n--- Asynchronous command queue ---")
+
+	queue := command.NewCommandQueue(command.QueueConfig{
+		Workers:     1, // A single worker keeps the demo output in priority order
+		MaxRetries:  2,
+		BaseBackoff: 5 * time.Millisecond,
+	})
+
+	ctx := context.Background()
+	garage, _ := queue.Submit(ctx, &flakyCommand{name: "Garage door", failures: 2}, 1)
+	sprinkler, _ := queue.Submit(ctx, &flakyCommand{name: "Sprinkler", failures: 5}, 0)
+	alarm, _ := queue.Submit(ctx, &flakyCommand{name: "Alarm"}, 10)
+
+	for _, future := range []*command.Future{garage, sprinkler, alarm} {
+		res := future.Wait()
+		fmt.Printf("Result: %s after %d attempt(s), err: %v\n", res.Command.(*flakyCommand).name, res.Attempts, res.Err)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	if err := queue.Shutdown(shutdownCtx); err != nil {
+		fmt.Printf("Shutdown: %v\n", err)
+	}
+}
+
+func schedulerDemo() {
+	fmt.Println("\n--- Scheduling commands on a fake clock ---")
+
+	// Friday, 06:00. A fake clock lets us skip through the weekend instantly.
+	clock := command.NewFakeClock(time.Date(2024, time.January, 5, 6, 0, 0, 0, time.UTC))
+	scheduler := command.NewScheduler(clock)
+
+	livingRoom := &command.Light{}
+	weekdayMorning, err := scheduler.ScheduleCron("0 7 * * mon-fri", command.NewTurnOnCommand(livingRoom))
+	if err != nil {
+		fmt.Printf("Could not schedule: %v\n", err)
+		return
+	}
+	scheduler.ScheduleAfter(90*time.Minute, command.NewTurnOffCommand(livingRoom))
+
+	for _, entry := range scheduler.Entries() {
+		fmt.Printf("Entry %d (%s) next at %s\n", entry.ID, entry.Schedule, entry.Next.Format("Mon 15:04"))
+	}
+
+	// Step through the next four days one hour at a time.
+	for hour := 0; hour < 4*24; hour++ {
+		clock.Advance(time.Hour)
+		if scheduler.RunDue() > 0 {
+			fmt.Printf("  ^ ran at %s\n", clock.Now().Format("Mon 15:04"))
+		}
+	}
+
+	scheduler.Pause(weekdayMorning)
+	clock.Advance(24 * time.Hour)
+	fmt.Printf("Paused entry ran %d time(s) on Tuesday\n", scheduler.RunDue())
+}
+
+func transactionDemo() {
+	fmt.Println("\n--- All-or-nothing transaction ---")
+
+	hall := &command.Light{}
+	kitchen := &command.Light{}
+	evening := command.NewTransactionCommand(
+		command.NewTurnOnCommand(hall),
+		command.NewTurnOnCommand(kitchen),
+		&flakyCommand{name: "Blinds", failures: 1},
+	)
+
+	// The blinds fail, so both lights are switched back off in reverse order.
+	err := evening.Execute()
+	var txErr *command.TransactionError
+	if errors.As(err, &txErr) {
+		fmt.Printf("Transaction failed at step %d, rolled back: %t\n", txErr.Step, txErr.RolledBack())
+	}
+	fmt.Printf("Hall on: %t, kitchen on: %t\n", hall.IsOn(), kitchen.IsOn())
+}
+
+func httpDemo() {
+	fmt.Println("\n--- Driving the remote over HTTP ---")
+
+	porch := &command.Light{}
+	api := command.NewServer(command.RealClock{})
+	api.RegisterDevice("porch", porch)
+	api.RegisterCommand("porch", "on", command.NewTurnOnCommand(porch))
+	api.RegisterCommand("porch", "off", command.NewTurnOffCommand(porch))
+
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	for _, req := range []struct{ method, path string }{
+		{http.MethodPost, "/devices/porch/commands/on"},
+		{http.MethodGet, "/devices/porch"},
+		{http.MethodPost, "/devices/porch/commands/dance"},
+	} {
+		httpReq, _ := http.NewRequest(req.method, server.URL+req.path, nil)
+		resp, err := http.DefaultClient.Do(httpReq)
+		if err != nil {
+			fmt.Printf("%s %s failed: %v\n", req.method, req.path, err)
+			continue
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		fmt.Printf("%s %s -> %d %s", req.method, req.path, resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,22 @@
+package main
+
+import "github.com/hardworking-gopher/GoF/structural/composite"
+
+func main() {
+	file1 := composite.NewFile("File1")
+	file2 := composite.NewFile("File2")
+	file3 := composite.NewFile("File3")
+
+	folder1 := composite.NewFolder("Folder1")
+
+	folder1.Add(file1)
+
+	folder2 := composite.NewFolder("Folder2")
+
+	folder2.Add(file2)
+	folder2.Add(file3)
+
+	folder2.Add(folder1)
+
+	folder2.Search("rose")
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hardworking-gopher/GoF/structural/decorator"
+)
+
+// --- Client Code ---
+func main() {
+	// Start with a simple coffee
+	myCoffee := &decorator.SimpleCoffee{}
+	fmt.Printf("Base Coffee: %s - $%.2f\n", myCoffee.GetDescription(), myCoffee.GetCost())
+
+	fmt.Println("\n--- Adding Condiments ---")
+
+	// Add Milk
+	milkCoffee := decorator.NewMilk(myCoffee)
+	fmt.Printf("Milk Coffee: %s - $%.2f\n", milkCoffee.GetDescription(), milkCoffee.GetCost())
+
+	// Add Sugar to the milk coffee
+	milkSugarCoffee := decorator.NewSugar(milkCoffee)
+	fmt.Printf("Milk & Sugar Coffee: %s - $%.2f\n", milkSugarCoffee.GetDescription(), milkSugarCoffee.GetCost())
+
+	// Start fresh, add caramel and then milk
+	caramelCoffee := decorator.NewCaramel(&decorator.SimpleCoffee{}) // Start with a new simple coffee
+	caramelMilkCoffee := decorator.NewMilk(caramelCoffee)
+	fmt.Printf("Caramel & Milk Coffee: %s - $%.2f\n", caramelMilkCoffee.GetDescription(), caramelMilkCoffee.GetCost())
+
+	fmt.Println("\n--- Complex Order ---")
+	// Order a coffee with milk, sugar, and extra caramel
+	complexCoffee := decorator.NewCaramel(decorator.NewSugar(decorator.NewMilk(&decorator.SimpleCoffee{})))
+	fmt.Printf("Complex Coffee: %s - $%.2f\n", complexCoffee.GetDescription(), complexCoffee.GetCost())
+
+	// Client code always interacts with the `Coffee` interface,
+	// regardless of how many decorators are applied.
+	describeAndCost(complexCoffee)
+}
+
+func describeAndCost(c decorator.Coffee) {
+	fmt.Printf("Final Order: %s | Total Cost: $%.2f\n", c.GetDescription(), c.GetCost())
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hardworking-gopher/GoF/structural/facade"
+)
+
+// --- Client Code ---
+func main() {
+	orderFacade := facade.NewOrderFacade()
+
+	// Client places an order without knowing the complex steps involved
+	fmt.Println("Attempting to place a small, normal order:")
+	err := orderFacade.PlaceOrder(
+		"Laptop-X1",
+		1,
+		899.99,
+		"1111-2222-3333-4444",
+		"alice@example.com",
+	)
+	if err != nil {
+		fmt.Printf("Order process failed: %v\n", err)
+	}
+
+	fmt.Println("\nAttempting to place a large order (should fail payment):")
+	err = orderFacade.PlaceOrder(
+		"Server-Rack",
+		1,
+		1500.00, // Amount over 1000.00 should fail payment
+		"5555-6666-7777-8888",
+		"bob@example.com",
+	)
+	if err != nil {
+		fmt.Printf("Order process failed: %v\n", err)
+	}
+
+	fmt.Println("\nAttempting to place an order with insufficient stock (should fail stock check):")
+	err = orderFacade.PlaceOrder(
+		"Widget-A",
+		15, // Quantity over 10 should fail stock check
+		50.00,
+		"9999-8888-7777-6666",
+		"charlie@example.com",
+	)
+	if err != nil {
+		fmt.Printf("Order process failed: %v\n", err)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hardworking-gopher/GoF/creational/factorymethod"
+)
+
+// --- Client Code ---
+func main() {
+	// Create an Email Notifier
+	emailConfig := map[string]string{"recipient": "user@example.com"}
+	emailNotifier, err := factorymethod.NewNotifier(factorymethod.TypeEmail, emailConfig)
+	if err != nil {
+		fmt.Println("Error creating email notifier:", err)
+	} else {
+		emailNotifier.Send("Hello via Email!")
+	}
+
+	fmt.Println()
+
+	// Create an SMS Notifier
+	smsConfig := map[string]string{"phone_number": "123-456-7890"}
+	smsNotifier, err := factorymethod.NewNotifier(factorymethod.TypeSMS, smsConfig)
+	if err != nil {
+		fmt.Println("Error creating SMS notifier:", err)
+	} else {
+		smsNotifier.Send("Hello via SMS!")
+	}
+
+	fmt.Println()
+
+	// Create a Push Notifier
+	pushConfig := map[string]string{"device_token": "abcdef123456"}
+	pushNotifier, err := factorymethod.NewNotifier(factorymethod.TypePush, pushConfig)
+	if err != nil {
+		fmt.Println("Error creating push notifier:", err)
+	} else {
+		pushNotifier.Send("Hello via Push!")
+	}
+
+	fmt.Println()
+
+	// Try to create an unknown notifier
+	unknownNotifier, err := factorymethod.NewNotifier("unknown_type", nil)
+	if err != nil {
+		fmt.Println("Error creating unknown notifier:", err)
+	} else {
+		unknownNotifier.Send("This won't happen.")
+	}
+}
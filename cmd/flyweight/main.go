@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hardworking-gopher/GoF/structural/flyweight"
+)
+
+func main() {
+	game := flyweight.NewGame()
+
+	//Add Terrorist
+	game.AddTerrorist(flyweight.TerroristDressType)
+	game.AddTerrorist(flyweight.TerroristDressType)
+	game.AddTerrorist(flyweight.TerroristDressType)
+	game.AddTerrorist(flyweight.TerroristDressType)
+
+	//Add CounterTerrorist
+	game.AddCounterTerrorist(flyweight.CounterTerroristDressType)
+	game.AddCounterTerrorist(flyweight.CounterTerroristDressType)
+	game.AddCounterTerrorist(flyweight.CounterTerroristDressType)
+
+	dressFactoryInstance := flyweight.GetDressFactorySingleInstance()
+
+	for dressType, dress := range dressFactoryInstance.Dresses() {
+		fmt.Printf("DressColorType: %s\nDressColor: %s\n", dressType, dress.GetColor())
+	}
+
+	uniquePointers := make(map[string]bool)
+	for _, player := range game.Players() {
+		uniquePointers[fmt.Sprintf("%p", player.Dress())] = true
+	}
+
+	// even though we have 10 players, they all share two pointers of the dress structs
+	fmt.Println(len(uniquePointers))
+}
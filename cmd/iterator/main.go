@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hardworking-gopher/GoF/behavioral/iterator"
+)
+
+// --- Client Code ---
+func main() {
+	// Create a concrete aggregate
+	library := iterator.NewBookCollection()
+	library.AddBook(&iterator.Book{Title: "The Lord of the Rings", Author: "J.R.R. Tolkien"})
+	library.AddBook(&iterator.Book{Title: "Pride and Prejudice", Author: "Jane Austen"})
+	library.AddBook(&iterator.Book{Title: "1984", Author: "George Orwell"})
+	library.AddBook(&iterator.Book{Title: "To Kill a Mockingbird", Author: "Harper Lee"})
+
+	// Get an iterator from the aggregate
+	// The client only interacts with the BookIterator interface.
+	// It doesn't know that internally BookCollection uses a slice.
+	it := library.CreateIterator()
+
+	fmt.Println("--- Iterating through the library (forward) ---")
+	for it.HasNext() {
+		book := it.Next()
+		fmt.Printf("Reading: %s\n", book)
+	}
+
+	fmt.Println("\n--- Resetting and iterating again ---")
+	it.Reset() // Reset the iterator to the beginning
+	for it.HasNext() {
+		book := it.Next()
+		fmt.Printf("Re-reading: %s\n", book)
+	}
+
+	// Example of another independent iteration
+	fmt.Println("\n--- Another independent iteration ---")
+	anotherIt := library.CreateIterator()
+	anotherIt.Next()                                                           // Advance this iterator once
+	fmt.Printf("First book from independent iterator: %s\n", anotherIt.Next()) // Get second book
+
+	fmt.Printf("Original iterator (it) is still at the end: HasNext() = %t\n", it.HasNext())
+
+	fmt.Println("\n--- Range-over-func iteration ---")
+	for book := range library.All() {
+		fmt.Printf("Ranging: %s\n", book)
+	}
+	for book := range library.Backward() {
+		fmt.Printf("Backward: %s\n", book)
+	}
+
+	// Adapters keep old and new code interoperable in both directions.
+	fmt.Println("\n--- Adapters ---")
+	classic := library.CreateIterator()
+	classic.Next() // Skip the first book using the classic protocol
+	for book := range iterator.Seq(classic) {
+		fmt.Printf("Classic iterator as a Seq: %s\n", book)
+	}
+
+	fromSeq := iterator.FromSeq(library.Backward())
+	defer fromSeq.Stop()
+	if fromSeq.HasNext() {
+		fmt.Printf("Seq as a classic iterator, first book: %s\n", fromSeq.Next())
+	}
+
+	fmt.Println("\n--- Lazy combinators ---")
+	// Nothing is copied into intermediate slices: each stage pulls one book at a time.
+	titles := iterator.Map(
+		iterator.Filter(library.All(), func(b *iterator.Book) bool { return len(b.Title) > 4 }),
+		func(b *iterator.Book) string { return b.Title },
+	)
+	for title := range iterator.Take(titles, 2) {
+		fmt.Printf("Long title: %s\n", title)
+	}
+
+	for pair := range iterator.Chunk(library.All(), 2) {
+		fmt.Printf("Shelf of %d: %s, %s\n", len(pair), pair[0].Title, pair[1].Title)
+	}
+
+	numbers := iterator.NewCollection(1, 1, 2, 3, 3, 3, 4)
+	fmt.Printf("Dedup: %v\n", iterator.Collect(iterator.Dedup(numbers.All())))
+	fmt.Printf("Sum of windows of 3: %v\n", iterator.Collect(iterator.Map(
+		iterator.Window(numbers.All(), 3),
+		func(w []int) int {
+			return iterator.Reduce(slices.Values(w), 0, func(acc, v int) int { return acc + v })
+		},
+	)))
+	for i, book := range iterator.Zip(numbers.All(), library.All()) {
+		fmt.Printf("Zip: %d -> %s\n", i, book.Title)
+	}
+
+	fmt.Println("\n--- Fail-fast and snapshot iteration ---")
+	failFast := library.CreateIterator()
+	snapshot := library.CreateSnapshotIterator()
+	library.AddBook(&iterator.Book{Title: "Dune", Author: "Frank Herbert"})
+
+	for failFast.HasNext() {
+		failFast.Next()
+	}
+	fmt.Printf("Fail-fast iterator stopped with: %v\n", failFast.Err())
+
+	count := 0
+	for snapshot.HasNext() {
+		snapshot.Next()
+		count++
+	}
+	fmt.Printf("Snapshot iterator saw %d books, the collection now has %d\n", count, library.Len())
+
+	paginationDemo(library)
+	indexDemo(library)
+	loaderDemo()
+}
+
+func loaderDemo() {
+	fmt.Println("\n--- Streaming loaders ---")
+	dump := `Author,Title,Year
+Frank Herbert,Dune,1965
+Ursula K. Le Guin,The Left Hand of Darkness
+,Nameless,1999
+Isaac Asimov,Foundation,1951
+`
+	strict := iterator.NewCSVBookIterator(strings.NewReader(dump), iterator.LoaderOptions{})
+	for book, err := range iterator.Seq2(strict) {
+		if err != nil {
+			fmt.Printf("CSV (strict) stopped with: %v\n", err)
+			break
+		}
+		fmt.Printf("CSV (strict): %s\n", book)
+	}
+
+	tolerant := iterator.NewCSVBookIterator(strings.NewReader(dump), iterator.LoaderOptions{Tolerant: true})
+	for book := range iterator.Seq(tolerant) {
+		fmt.Printf("CSV (tolerant): %s\n", book)
+	}
+	for _, skipped := range tolerant.Skipped() {
+		fmt.Printf("CSV (tolerant) skipped %v\n", skipped)
+	}
+
+	jsonl := iterator.NewJSONLBookIterator(strings.NewReader(`{"title": "Neuromancer", "author": "William Gibson"}
+{"title": "Snow Crash", "author": "Neal Stephenson"`), iterator.LoaderOptions{})
+	for book := range iterator.Seq(jsonl) {
+		fmt.Printf("JSONL: %s\n", book)
+	}
+	fmt.Printf("JSONL stopped with: %v\n", jsonl.Err())
+}
+
+func indexDemo(library *iterator.BookCollection) {
+	fmt.Println("\n--- Indexed iteration ---")
+	library.AddBook(&iterator.Book{Title: "Animal Farm", Author: "George Orwell"})
+	library.AddBook(&iterator.Book{Title: "Harry Potter and the Philosopher's Stone", Author: "J.K. Rowling"})
+	library.AddBook(&iterator.Book{Title: "The Hobbit", Author: "J.R.R. Tolkien"})
+
+	for book := range library.Ordered(iterator.ByAuthor) {
+		fmt.Printf("By author: %s\n", book)
+	}
+	for book := range library.WithPrefix(iterator.ByAuthor, "Ha") {
+		fmt.Printf("Author starts with 'Ha': %s\n", book)
+	}
+	for book := range library.InRange(iterator.ByTitle, "A", "P") {
+		fmt.Printf("Title in [A, P): %s\n", book)
+	}
+
+	// Scans resume by key, so removing books from inside the loop is safe.
+	for book := range library.WithPrefix(iterator.ByAuthor, "George") {
+		library.RemoveBook(book)
+	}
+	fmt.Printf("After removing George Orwell: %d books, first title is %s\n",
+		library.Len(), iterator.Collect(iterator.Take(library.Ordered(iterator.ByTitle), 1))[0].Title)
+}
+
+func paginationDemo(library *iterator.BookCollection) {
+	fmt.Println("\n--- Paginated iteration ---")
+	ctx := context.Background()
+	fetcher := iterator.NewFakePageFetcher(iterator.Collect(library.All()), 20*time.Millisecond)
+
+	// Prefetch two pages in the background while the current one is being read.
+	pages, err := iterator.NewPaginatedIterator(ctx, fetcher, iterator.PaginationConfig{PageSize: 2, Prefetch: 2})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for range 3 {
+		fmt.Printf("Page reader: %s\n", pages.Next())
+	}
+	token := pages.Cursor()
+	pages.Stop()
+
+	// Resume from the token, this time against a flaky source.
+	fetcher.Fail = func(call int, cursor string) error {
+		if cursor == "4" {
+			return errors.New("503 service unavailable")
+		}
+		return nil
+	}
+	resumed, err := iterator.NewPaginatedIterator(ctx, fetcher, iterator.PaginationConfig{PageSize: 2, Resume: token})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for resumed.HasNext() {
+		fmt.Printf("Resumed reader: %s\n", resumed.Next())
+	}
+	fmt.Printf("Resumed reader stopped with: %v (after %d fetches)\n", resumed.Err(), fetcher.Calls())
+}
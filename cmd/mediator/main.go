@@ -0,0 +1,14 @@
+package main
+
+import "github.com/hardworking-gopher/GoF/behavioral/mediator"
+
+func main() {
+	stationManager := mediator.NewStationManager()
+
+	passengerTrain := mediator.NewPassengerTrain(stationManager)
+	freightTrain := mediator.NewFreightTrain(stationManager)
+
+	passengerTrain.Arrive()
+	freightTrain.Arrive()
+	passengerTrain.Depart()
+}
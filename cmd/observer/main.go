@@ -0,0 +1,495 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hardworking-gopher/GoF/behavioral/observer"
+)
+
+// --- Client Code ---
+func main() {
+	// Create the Subject
+	weatherStation := observer.NewWeatherStation()
+	defer weatherStation.Close()
+
+	// Create Concrete Observers
+	currentDisplay1 := observer.NewCurrentConditionsDisplay("Living Room Display")
+	forecastDisplay1 := observer.NewForecastDisplay("Kitchen Forecast")
+	currentDisplay2 := observer.NewCurrentConditionsDisplay("Bedroom Display")
+
+	// Register observers with the subject
+	weatherStation.RegisterObserver(currentDisplay1)
+	weatherStation.RegisterObserver(forecastDisplay1)
+	weatherStation.RegisterObserver(currentDisplay2)
+
+	// Displays can show values in their own units.
+	currentDisplay2.SetUnits(observer.MetricUnits)
+	forecastDisplay1.SetPressureUnit(observer.InchesOfMercury)
+
+	// Simulate weather changes - observers get notified automatically.
+	// Our sensor reports pressure in inches of mercury, so say so.
+	// Delivery is asynchronous, so Flush waits for the displays before moving on.
+	setReading(weatherStation, 80, 65, 30.4)
+	weatherStation.Flush()
+	setReading(weatherStation, 82, 70, 29.2)
+	weatherStation.Flush()
+
+	// Deregister an observer
+	weatherStation.DeregisterObserver(currentDisplay2)
+
+	// Simulate another weather change - only remaining observers get notified
+	setReading(weatherStation, 78, 90, 29.8)
+	weatherStation.Flush()
+
+	// Try to deregister an observer that's already gone
+	weatherStation.DeregisterObserver(currentDisplay2) // Will show no effect as it's already deleted
+
+	// A unit mix-up is rejected instead of being published.
+	err := weatherStation.SetReading(
+		observer.Measurement{Value: 26, Unit: observer.Celsius},
+		observer.Measurement{Value: 1013, Unit: observer.Hectopascal},
+		observer.Measurement{Value: 60, Unit: observer.RelativeHumidity},
+	)
+	fmt.Printf("\nSwapped humidity and pressure: %v\n", err)
+
+	unitsDemo()
+	overflowDemo()
+	selfDeregisterDemo()
+	busDemo()
+	filterDemo()
+	statisticsDemo()
+	recordingDemo()
+	sseDemo()
+	ingestionDemo()
+}
+
+// setReading publishes a reading from our sensor, which reports °F, %RH and inHg.
+func setReading(station *observer.WeatherStation, fahrenheit, humidity, inHg float64) {
+	err := station.SetReading(
+		observer.Measurement{Value: fahrenheit, Unit: observer.Fahrenheit},
+		observer.Measurement{Value: humidity, Unit: observer.RelativeHumidity},
+		observer.Measurement{Value: inHg, Unit: observer.InchesOfMercury},
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func unitsDemo() {
+	fmt.Println("\n--- Unit conversions ---")
+	pressure := observer.Measurement{Value: 30.4, Unit: observer.InchesOfMercury}
+	for _, unit := range []observer.Unit{observer.Hectopascal, observer.Kilopascal} {
+		converted, _ := pressure.In(unit)
+		fmt.Printf("%v = %v\n", pressure, converted)
+	}
+	temperature := observer.Measurement{Value: 80, Unit: observer.Fahrenheit}
+	for _, unit := range []observer.Unit{observer.Celsius, observer.Kelvin} {
+		converted, _ := temperature.In(unit)
+		fmt.Printf("%v = %v\n", temperature, converted)
+	}
+	humidity := observer.Measurement{Value: 65, Unit: observer.RelativeHumidity}
+	absolute, _ := humidity.HumidityIn(observer.AbsoluteHumidity, temperature)
+	fmt.Printf("%v relative humidity at %v = %v\n", humidity, temperature, absolute)
+	if _, err := pressure.In(observer.Celsius); err != nil {
+		fmt.Printf("Converting pressure to a temperature: %v\n", err)
+	}
+}
+
+// slowDisplay takes a while to render and remembers the temperatures it was shown.
+type slowDisplay struct {
+	name  string
+	delay time.Duration
+	mu    sync.Mutex
+	seen  []float64
+}
+
+func (d *slowDisplay) GetName() string { return d.name }
+
+func (d *slowDisplay) Update(temperature, humidity, pressure float64) {
+	time.Sleep(d.delay)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.seen = append(d.seen, temperature)
+}
+
+func (d *slowDisplay) Seen() []float64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.seen
+}
+
+func overflowDemo() {
+	fmt.Println("\n--- Overflow policies for a slow display ---")
+	for _, policy := range []observer.OverflowPolicy{observer.Block, observer.DropOldest, observer.DropNewest, observer.CoalesceLatest} {
+		station := observer.NewWeatherStation()
+		display := &slowDisplay{name: "E-ink " + policy.String(), delay: 20 * time.Millisecond}
+		station.RegisterObserverWith(display, observer.DeliveryOptions{QueueSize: 2, Overflow: policy})
+
+		start := time.Now()
+		for t := 70.0; t < 76; t++ {
+			station.SetMeasurements(t, 50, 1013)
+		}
+		publishing := time.Since(start).Round(10 * time.Millisecond)
+		station.Flush()
+		fmt.Printf("%s: publishing took ~%v, display saw %v, dropped %d\n",
+			policy, publishing, display.Seen(), station.Dropped(display))
+		station.Close()
+	}
+}
+
+// oneShotDisplay deregisters itself from inside its first Update.
+type oneShotDisplay struct {
+	station *observer.WeatherStation
+}
+
+func (d *oneShotDisplay) GetName() string { return "One-shot Alert" }
+
+func (d *oneShotDisplay) Update(temperature, humidity, pressure float64) {
+	fmt.Printf("[%s] First reading: %.1fF, unsubscribing\n", d.GetName(), temperature)
+	d.station.DeregisterObserver(d)
+}
+
+func selfDeregisterDemo() {
+	fmt.Println("\n--- Deregistering from inside Update ---")
+	station := observer.NewWeatherStation()
+	defer station.Close()
+	station.RegisterObserver(&oneShotDisplay{station: station})
+	station.SetMeasurements(75, 40, 1012)
+	station.Flush()
+	station.SetMeasurements(76, 41, 1011)
+	station.Flush()
+}
+
+func busDemo() {
+	fmt.Println("\n--- Typed event bus ---")
+	bus := observer.NewBus[observer.Reading]()
+
+	kitchen, err := bus.Subscribe("weather.kitchen.reading", func(topic string, r observer.Reading) {
+		fmt.Printf("[kitchen] %s: %.1fF\n", topic, r.Temperature)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := bus.Subscribe("weather.*.reading", func(topic string, r observer.Reading) {
+		fmt.Printf("[every room] %s: %.1f%% humidity\n", topic, r.Humidity)
+	}); err != nil {
+		log.Fatal(err)
+	}
+	if _, err := bus.Subscribe("weather.#", func(topic string, r observer.Reading) {
+		if r.Temperature > 100 {
+			panic("sensor out of range")
+		}
+	}); err != nil {
+		log.Fatal(err)
+	}
+
+	bus.Publish("weather.kitchen.reading", observer.Reading{Temperature: 72, Humidity: 40})
+	bus.Publish("weather.attic.reading", observer.Reading{Temperature: 88, Humidity: 30})
+
+	kitchen.Unsubscribe()
+	n, err := bus.Publish("weather.kitchen.reading", observer.Reading{Temperature: 140, Humidity: 10})
+	fmt.Printf("After unsubscribing the kitchen: %d handlers called, error: %v\n", n, err)
+
+	// Displays are registered by identity, so sharing a name no longer overwrites.
+	station := observer.NewWeatherStation()
+	defer station.Close()
+	station.RegisterObserver(observer.NewCurrentConditionsDisplay("Hallway Display"))
+	upstairs := station.RegisterObserverWith(observer.NewCurrentConditionsDisplay("Hallway Display"), observer.DeliveryOptions{})
+	station.SetMeasurements(68, 45, 1015)
+	station.Flush()
+	upstairs.Unsubscribe()
+	station.SetMeasurements(69, 44, 1016)
+	station.Flush()
+}
+
+// alertDisplay prints whatever the station decided to deliver to it.
+type alertDisplay struct {
+	name string
+}
+
+func (d *alertDisplay) GetName() string { return d.name }
+
+func (d *alertDisplay) Update(temperature, humidity, pressure float64) {
+	fmt.Printf("[%s] %.1fF, %.0f%%, %.0f hPa\n", d.name, temperature, humidity, pressure)
+}
+
+func filterDemo() {
+	fmt.Println("\n--- Filtered subscriptions ---")
+	station := observer.NewWeatherStation()
+	defer station.Close()
+
+	station.RegisterObserverWith(&alertDisplay{name: "Storm Alert"}, observer.DeliveryOptions{
+		Filters: []observer.Filter{observer.Below(observer.Pressure, 1000)},
+	})
+	station.RegisterObserverWith(&alertDisplay{name: "Big Swings"}, observer.DeliveryOptions{
+		Filters: []observer.Filter{observer.ChangedBy(observer.Temperature, 2)},
+	})
+	station.RegisterObserverWith(&alertDisplay{name: "Changes Only"}, observer.DeliveryOptions{
+		Filters: []observer.Filter{observer.OnChange()},
+	})
+
+	for _, r := range []observer.Reading{
+		{Temperature: 70, Humidity: 50, Pressure: 1012},
+		{Temperature: 70, Humidity: 50, Pressure: 1012},
+		{Temperature: 71, Humidity: 55, Pressure: 1003},
+		{Temperature: 73, Humidity: 60, Pressure: 998},
+		{Temperature: 73.5, Humidity: 65, Pressure: 995},
+	} {
+		station.SetMeasurements(r.Temperature, r.Humidity, r.Pressure)
+		station.Flush()
+	}
+
+	// A burst of readings reaches a debounced display only once things settle down.
+	gusty := observer.NewWeatherStation()
+	defer gusty.Close()
+	gusty.RegisterObserverWith(&alertDisplay{name: "Settled"}, observer.DeliveryOptions{
+		Debounce: 50 * time.Millisecond,
+	})
+	for t := 60.0; t < 65; t++ {
+		gusty.SetMeasurements(t, 40, 1010)
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond) // Let the debounce window pass instead of flushing early
+	gusty.Flush()
+}
+
+func statisticsDemo() {
+	fmt.Println("\n--- Statistics, comfort and trend displays ---")
+	station := observer.NewWeatherStation()
+	defer station.Close()
+
+	stats := observer.NewStatisticsDisplay("Statistics", time.Hour)
+	comfort := observer.NewComfortDisplay("Comfort")
+	forecast := observer.NewTrendForecastDisplay("Barometer", 4)
+	station.RegisterObserver(stats)
+	station.RegisterObserver(comfort)
+	station.RegisterObserver(forecast)
+
+	for _, r := range []observer.Reading{
+		{Temperature: 84, Humidity: 55, Pressure: 1016},
+		{Temperature: 88, Humidity: 60, Pressure: 1012},
+		{Temperature: 91, Humidity: 62, Pressure: 1007},
+		{Temperature: 86, Humidity: 70, Pressure: 1001},
+	} {
+		station.SetMeasurements(r.Temperature, r.Humidity, r.Pressure)
+		station.Flush()
+	}
+
+	// The computed values are available to code, not just printed.
+	temperature := stats.Stats(observer.Temperature)
+	fmt.Printf("\nTemperature over the last hour: min %.1f, max %.1f, mean %.2f, p90 %.1f\n",
+		temperature.Min, temperature.Max, temperature.Mean, stats.Percentile(observer.Temperature, 90))
+	fmt.Printf("Heat index %.1fF, dew point %.1fF, pressure %s: %s\n",
+		comfort.HeatIndex(), comfort.DewPoint(), forecast.Trend(), forecast.Forecast())
+}
+
+func recordingDemo() {
+	fmt.Println("\n--- Record and replay ---")
+	dir, err := os.MkdirTemp("", "weather")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "field.wxr")
+
+	// Record a short burst of field data.
+	field := observer.NewWeatherStation()
+	recorder, err := observer.CreateRecording("Recorder", path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	field.RegisterObserver(recorder)
+	for i := range 5 {
+		field.SetMeasurements(70+float64(i), 50, 1013-float64(i))
+		time.Sleep(20 * time.Millisecond)
+	}
+	field.Close()
+	if err := recorder.Close(); err != nil {
+		log.Fatal(err)
+	}
+	info, _ := os.Stat(path)
+	fmt.Printf("\nRecorded %d readings in %d bytes\n", recorder.Count(), info.Size())
+
+	// Replay it into a fresh station, at 4x speed and then as fast as possible.
+	for _, speed := range []float64{4, observer.AsFastAsPossible} {
+		lab := observer.NewWeatherStation()
+		forecast := observer.NewTrendForecastDisplay("Lab Barometer", 5)
+		lab.RegisterObserver(forecast)
+		f, err := os.Open(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		start := time.Now()
+		n, err := observer.Replay(context.Background(), lab, f, speed)
+		f.Close()
+		if err != nil {
+			log.Fatal(err)
+		}
+		lab.Flush()
+		fmt.Printf("\nReplayed %d readings at speed %v in ~%v, trend: %s\n",
+			n, speed, time.Since(start).Round(5*time.Millisecond), forecast.Trend())
+		lab.Close()
+	}
+}
+
+func sseDemo() {
+	fmt.Println("\n--- Server-Sent Events ---")
+	station := observer.NewWeatherStation()
+	defer station.Close()
+	broadcaster := observer.NewSSEBroadcaster("Wall Dashboards", observer.SSEOptions{})
+	station.RegisterObserver(broadcaster)
+
+	server := httptest.NewServer(broadcaster)
+	defer server.Close()
+	defer broadcaster.Close() // End the streams first, so server.Close does not wait on them
+
+	// A dashboard that only cares about temperature.
+	ctx, disconnect := context.WithCancel(context.Background())
+	events := connectSSE(ctx, server.URL+"?metrics=temperature", "")
+	waitForClients(broadcaster, 1)
+	for t := 70.0; t < 73; t++ {
+		station.SetMeasurements(t, 50, 1013)
+	}
+	var lastID string
+	for range 3 {
+		ev := <-events
+		fmt.Printf("\nDashboard got #%s: %s", ev.id, ev.data)
+		lastID = ev.id
+	}
+	disconnect()
+	waitForClients(broadcaster, 0)
+
+	// Readings published while the dashboard was offline are replayed on reconnect.
+	station.SetMeasurements(73, 52, 1011)
+	station.SetMeasurements(74, 54, 1009)
+	station.Flush()
+	ctx, disconnect = context.WithCancel(context.Background())
+	defer disconnect()
+	events = connectSSE(ctx, server.URL+"?metrics=temperature,pressure", lastID)
+	for range 2 {
+		ev := <-events
+		fmt.Printf("\nResumed dashboard got #%s: %s", ev.id, ev.data)
+	}
+	fmt.Println()
+}
+
+type sseEvent struct {
+	id   string
+	data string
+}
+
+// connectSSE streams the events of url on the returned channel until ctx is cancelled.
+func connectSSE(ctx context.Context, url, lastEventID string) <-chan sseEvent {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		log.Fatal(err)
+	}
+	events := make(chan sseEvent)
+	go func() {
+		defer resp.Body.Close()
+		var ev sseEvent
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "id: "):
+				ev.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "data: "):
+				ev.data = strings.TrimPrefix(line, "data: ")
+			case line == "" && ev.data != "":
+				select {
+				case events <- ev:
+				case <-ctx.Done():
+					return
+				}
+				ev = sseEvent{}
+			}
+		}
+	}()
+	return events
+}
+
+func waitForClients(b *observer.SSEBroadcaster, n int) {
+	for b.Clients() != n {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func ingestionDemo() {
+	fmt.Println("\n--- Ingestion sources ---")
+	station := observer.NewWeatherStation()
+	defer station.Close()
+	conditions := observer.NewCurrentConditionsDisplay("Lobby Display")
+	conditions.SetUnits(observer.MetricUnits)
+	station.RegisterObserver(conditions)
+	ctx := context.Background()
+
+	metar := observer.NewMETARSource("KJFK METAR", strings.NewReader(`METAR KJFK 121851Z 18012KT 10SM FEW250 28/17 A3002 RMK AO2 SLP165
+METAR EGLL 121850Z 24008KT 9999 SCT030 M02/M05 Q1021
+METAR KXXX 121851Z 00000KT 10SM CLR 28/17 A9999
+SPECI KBOS 121901Z 09015G25KT 2SM RA BR OVC008
+`), observer.SourceOptions{})
+
+	sensorLog := observer.NewCSVSource("Roof Sensor Log", strings.NewReader(`timestamp,temperature,humidity,pressure
+2024-06-12T18:00:00Z,24.5,48,1012.8
+2024-06-12T18:05:00Z,24.9,47
+2024-06-12T18:10:00Z,250.1,47,1012.6
+2024-06-12T18:15:00Z,25.2,46,1012.4
+`), observer.MetricUnits, observer.SourceOptions{})
+
+	udp, err := observer.ListenUDP("Garden Sensor", "127.0.0.1:0", observer.SourceOptions{StaleAfter: time.Minute})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, src := range []observer.Source{metar, sensorLog} {
+		if err := src.Run(ctx, station); err != nil {
+			log.Fatal(err)
+		}
+		station.Flush()
+	}
+
+	// A local UDP client stands in for the hardware.
+	udpCtx, stopUDP := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() { done <- udp.Run(udpCtx, station) }()
+	sensor, err := net.Dial("udp", udp.Addr().String())
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Fprint(sensor, "id=garden-1 t=71.2F h=55% p=30.01inHg\nid=garden-1 t=71.4F h=155% p=30.01inHg")
+	fmt.Fprint(sensor, "id=garden-1 temperature=71")
+	sensor.Close()
+	for h := udp.Health(); h.Accepted+h.Rejected < 3; h = udp.Health() {
+		time.Sleep(time.Millisecond)
+	}
+	station.Flush()
+	fmt.Printf("\nGarden sensor while running: %s\n", udp.Health().Status)
+	stopUDP()
+	<-done
+
+	fmt.Println()
+	for _, src := range []observer.Source{metar, sensorLog, udp} {
+		h := src.Health()
+		fmt.Printf("%-16s %-8s accepted %d, rejected %d, last error: %v\n",
+			h.Source, h.Status, h.Accepted, h.Rejected, h.LastError)
+	}
+}
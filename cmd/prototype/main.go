@@ -1,34 +1,36 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/hardworking-gopher/GoF/creational/prototype"
+)
 
 func main() {
 	// Create an initial prototype folder structure
-	folder1 := &Folder{
-		name: "Folder1",
-		children: []Inode{
-			&File{name: "File1-1"},
-			&File{name: "File1-2"},
-		},
-	}
+	folder1 := prototype.NewFolder(
+		"Folder1",
+		prototype.NewFile("File1-1"),
+		prototype.NewFile("File1-2"),
+	)
 
 	fmt.Println("--- Original Structure ---")
-	folder1.print("  ")
+	folder1.Print("  ")
 
 	// Clone the entire folder structure
-	folder2 := folder1.clone()
+	folder2 := folder1.Clone()
 
 	// You can now treat folder2 as a completely new object
 	// For demonstration, let's cast it back to a Folder to modify it
-	if cloned, ok := folder2.(*Folder); ok {
-		cloned.name = "Folder2 (Cloned)"
+	if cloned, ok := folder2.(*prototype.Folder); ok {
+		cloned.SetName("Folder2 (Cloned)")
 		// Add a new file to the cloned folder to show it's independent
-		cloned.children = append(cloned.children, &File{name: "File2-1 (New)"})
+		cloned.Add(prototype.NewFile("File2-1 (New)"))
 	}
 
 	fmt.Println("\n--- Cloned and Modified Structure ---")
-	folder2.print("  ")
+	folder2.Print("  ")
 
 	fmt.Println("\n--- Original Structure (Unchanged) ---")
-	folder1.print("  ")
+	folder1.Print("  ")
 }
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hardworking-gopher/GoF/structural/proxy"
+)
+
+// --- Client Code ---
+func main() {
+	// Create a real document
+	secretDoc := proxy.NewRealDocument("This is highly confidential information.")
+
+	// --- Scenario 1: Admin user accessing document ---
+	adminUser := &proxy.User{Name: "Admin", Roles: []string{"admin"}}
+	adminProxy := proxy.NewDocumentProtectionProxy(secretDoc, adminUser)
+	fmt.Println("--- Admin User Attempt ---")
+	content, err := adminProxy.ReadContent()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	} else {
+		fmt.Printf("Admin read: '%s'\n", content)
+	}
+
+	fmt.Println("\n--- Viewer User Attempt ---")
+	// --- Scenario 2: Viewer user accessing document ---
+	viewerUser := &proxy.User{Name: "Viewer", Roles: []string{"viewer"}}
+	viewerProxy := proxy.NewDocumentProtectionProxy(secretDoc, viewerUser)
+	content, err = viewerProxy.ReadContent()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	} else {
+		fmt.Printf("Viewer read: '%s'\n", content)
+	}
+
+	fmt.Println("\n--- Guest User Attempt (No role) ---")
+	// --- Scenario 3: Guest user (no specific role) accessing document ---
+	guestUser := &proxy.User{Name: "Guest", Roles: []string{"guest"}} // Or just {}
+	guestProxy := proxy.NewDocumentProtectionProxy(secretDoc, guestUser)
+	content, err = guestProxy.ReadContent()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	} else {
+		fmt.Printf("Guest read: '%s'\n", content) // This should not happen
+	}
+
+	fmt.Println("\n--- Unauthenticated Attempt ---")
+	// --- Scenario 4: Nil user (unauthenticated) accessing document ---
+	unauthProxy := proxy.NewDocumentProtectionProxy(secretDoc, nil)
+	content, err = unauthProxy.ReadContent()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	} else {
+		fmt.Printf("Unauthenticated read: '%s'\n", content) // This should not happen
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hardworking-gopher/GoF/behavioral/command"
+)
+
+const help = `Commands:
+  <device>.<action> [args]       e.g. living.dim 40, thermostat.set 21.5, bedroom.open
+  scene <name> = <cmd>; <cmd>    define a scene that applies all commands or none
+  run <name>                     run a scene
+  undo | redo                    walk through the history
+  state                          print every device
+  help | quit`
+
+// A small text REPL for the Command example. Lines can also be piped in to script a
+// scenario, e.g. go run ./cmd/remote < cmd/remote/evening.txt
+func main() {
+	thermostat, err := command.NewThermostat(20, command.ModeHeat)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	devices := map[string]command.Device{
+		"hall":       &command.Light{},
+		"living":     &command.DimmableLight{},
+		"thermostat": thermostat,
+		"bedroom":    &command.Blind{},
+	}
+	registry := command.NewRegistry()
+	for name, device := range devices {
+		registry.RegisterReceiver(name, device)
+	}
+
+	remote := command.NewRemoteControl(50)
+	scenes := make(map[string]command.Command)
+
+	fmt.Println(help)
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Print("remote> ")
+		if !scanner.Scan() {
+			fmt.Println()
+			return
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		word, rest, _ := strings.Cut(line, " ")
+		switch word {
+		case "quit", "exit":
+			return
+		case "help":
+			fmt.Println(help)
+		case "state":
+			printState(devices)
+		case "undo":
+			remote.PressUndo() // The remote reports failures itself
+		case "redo":
+			remote.PressRedo()
+		case "scene":
+			name, body, ok := strings.Cut(rest, "=")
+			name = strings.TrimSpace(name)
+			if !ok || name == "" {
+				fmt.Println("Usage: scene <name> = <cmd>; <cmd>")
+				continue
+			}
+			scene, err := parseScene(registry, body)
+			if err != nil {
+				fmt.Printf("Invalid scene: %v\n", err)
+				continue
+			}
+			scenes[name] = scene
+			fmt.Printf("Scene %q saved.\n", name)
+		case "run":
+			scene, ok := scenes[strings.TrimSpace(rest)]
+			if !ok {
+				fmt.Printf("Unknown scene %q.\n", strings.TrimSpace(rest))
+				continue
+			}
+			execute(remote, scene)
+		default:
+			cmd, err := registry.ParseLine(line)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				continue
+			}
+			execute(remote, cmd)
+		}
+	}
+}
+
+func parseScene(registry *command.Registry, body string) (command.Command, error) {
+	var steps []command.Command
+	for _, part := range strings.Split(body, ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		cmd, err := registry.ParseLine(part)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, cmd)
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("scene has no commands")
+	}
+	return command.NewTransactionCommand(steps...), nil
+}
+
+func execute(remote *command.RemoteControl, cmd command.Command) {
+	remote.SetCommand(cmd)
+	remote.PressButton() // Failed commands are reported and kept out of the history
+}
+
+func printState(devices map[string]command.Device) {
+	names := make([]string, 0, len(devices))
+	for name := range devices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		state, _ := json.Marshal(devices[name].State())
+		fmt.Printf("  %-10s %s\n", name, state)
+	}
+}
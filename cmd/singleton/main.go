@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hardworking-gopher/GoF/creational/singleton"
+)
+
+func main() {
+	// We'll use a WaitGroup to simulate concurrent access.
+	var wg sync.WaitGroup
+
+	// Start 100 goroutines that all try to get the instance.
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			conn := singleton.GetDBInstance()
+			fmt.Printf("Goroutine %d got connection string: %s\n", i, conn.GetConnectionString())
+		}(i)
+	}
+
+	wg.Wait()
+
+	// All goroutines will receive the same instance, and the creation message
+	// will only be printed once.
+	fmt.Println("\nFinished. All goroutines used the same singleton instance.")
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hardworking-gopher/GoF/behavioral/state"
+)
+
+func main() {
+	vendingMachine := state.NewVendingMachine(1, 10)
+
+	err := vendingMachine.RequestItem()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = vendingMachine.InsertMoney(10)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = vendingMachine.DispenseItem()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println()
+
+	err = vendingMachine.AddItem(2)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println()
+
+	err = vendingMachine.RequestItem()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = vendingMachine.InsertMoney(10)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = vendingMachine.DispenseItem()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
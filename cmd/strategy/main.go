@@ -0,0 +1,147 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hardworking-gopher/GoF/behavioral/strategy"
+)
+
+// --- Client Code ---
+func main() {
+	// Create a shopping cart with a total amount
+	cart1 := strategy.NewShoppingCart(strategy.MustParseMoney("120.50 USD"))
+
+	// --- Scenario 1: Pay with Credit Card ---
+	fmt.Println("\n--- Shopping Cart 1: Paying with Credit Card ---")
+	creditCard := strategy.NewCreditCardPayment("1234-5678-9012-3456", "123")
+	cart1.SetPaymentStrategy(creditCard)
+	err := cart1.Checkout()
+	if err != nil {
+		fmt.Printf("Checkout failed: %v\n", err)
+	}
+
+	// --- Scenario 2: Pay with PayPal ---
+	cart2 := strategy.NewShoppingCart(strategy.MustParseMoney("350.00 USD"))
+	fmt.Println("\n--- Shopping Cart 2: Paying with PayPal ---")
+	payPal := strategy.NewPayPalPayment("user@example.com")
+	cart2.SetPaymentStrategy(payPal)
+	err = cart2.Checkout()
+	if err != nil {
+		fmt.Printf("Checkout failed: %v\n", err)
+	}
+
+	// --- Scenario 3: Pay with Crypto (high amount, might fail strategy specific check) ---
+	cart3 := strategy.NewShoppingCart(strategy.NewMoney(0, strategy.USD)) // Amount below the one-cent minimum for crypto
+	fmt.Println("\n--- Shopping Cart 3: Paying with Crypto (low amount) ---")
+	crypto := strategy.NewCryptocurrencyPayment("0xAbc123...", "ETH")
+	cart3.SetPaymentStrategy(crypto)
+	err = cart3.Checkout()
+	if err != nil {
+		fmt.Printf("Checkout failed: %v\n", err)
+	}
+
+	// --- Scenario 4: Routed payment (credit card fails, the router falls back) ---
+	router := strategy.NewPaymentRouter(
+		strategy.RoutingRule{
+			Name: "large",
+			Min:  strategy.MustParseMoney("500.01 USD"),
+			Candidates: []strategy.Candidate{
+				{Name: "card", Strategy: strategy.NewCreditCardPayment("1111-2222-3333-4444", "456")},
+				{Name: "paypal", Strategy: strategy.NewPayPalPayment("backup@example.com")},
+				{Name: "crypto", Strategy: strategy.NewCryptocurrencyPayment("0xAbc123...", "ETH")},
+			},
+		},
+		strategy.RoutingRule{
+			Name:  "vip",
+			Tiers: []strategy.CustomerTier{strategy.Gold, strategy.Platinum},
+			Candidates: []strategy.Candidate{
+				// A blocked card is a hard decline: PayPal is never tried.
+				{Name: "card (blocked)", Strategy: blockedCard{number: "5555-6666-7777-8888"}},
+				{Name: "paypal", Strategy: strategy.NewPayPalPayment("vip@example.com")},
+			},
+		},
+		strategy.RoutingRule{
+			Name:       "everyday",
+			Currencies: []strategy.Currency{strategy.USD},
+			Candidates: []strategy.Candidate{
+				{Name: "paypal", Strategy: strategy.NewPayPalPayment("user@example.com")},
+				{Name: "card", Strategy: strategy.NewCreditCardPayment("1234-5678-9012-3456", "123")},
+			},
+		},
+	)
+
+	cart4 := strategy.NewShoppingCart(strategy.MustParseMoney("1500.00 USD"))
+	fmt.Println("\n--- Shopping Cart 4: Routed payment with fallback ---")
+	cart4.SetPaymentStrategy(router)
+	if err := cart4.Checkout(); err != nil {
+		fmt.Printf("Checkout failed: %v\n", err)
+	}
+	fmt.Println("Report:", router.LastReport())
+
+	fmt.Println("\n--- Shopping Cart 5: Gold customer, hard decline stops routing ---")
+	gold := router.ForCustomer(strategy.Gold)
+	if _, err := gold.Route(strategy.MustParseMoney("80.00 USD")); err != nil {
+		var decline *strategy.DeclineError
+		if errors.As(err, &decline) && !decline.Retryable {
+			fmt.Printf("Hard decline by %s: %s\n", decline.Method, decline.Reason)
+		}
+	}
+	fmt.Println("Report:", gold.LastReport())
+
+	fmt.Println("\n--- Shopping Cart 6: No route for a euro payment ---")
+	report, err := router.Route(strategy.MustParseMoney("EUR 40.00"))
+	fmt.Printf("Checkout failed: %v (no route: %t)\n", err, errors.Is(err, strategy.ErrNoRoute))
+	fmt.Println("Report:", report)
+
+	moneyDemo()
+}
+
+// blockedCard is a card its issuer refuses outright. The bundled strategies only
+// decline softly, so the demo brings its own to show a hard decline.
+type blockedCard struct {
+	number string
+}
+
+func (c blockedCard) Pay(amount strategy.Money) error {
+	fmt.Printf("Processing credit card payment of %v using card %s...\n", amount, c.number)
+	return &strategy.DeclineError{Method: "credit card", Reason: "rejected: card " + c.number + " is blocked"}
+}
+
+// moneyDemo shows why carts hold Money rather than float64.
+func moneyDemo() {
+	fmt.Println("\n--- Money: exact totals, allocation and parsing ---")
+	a, b := 0.10, 0.20
+	fmt.Printf("float64: 0.10 + 0.20 = %v\n", a+b)
+
+	cart := strategy.NewShoppingCart(strategy.NewMoney(0, strategy.USD))
+	for _, price := range []string{"0.10 USD", "0.20 USD"} {
+		if err := cart.AddItem(strategy.MustParseMoney(price)); err != nil {
+			log.Fatal(err)
+		}
+	}
+	fmt.Printf("Money:   $0.10 + $0.20 = %v\n", cart.Total())
+
+	// Three friends split a $100 bill; the odd cent is not lost.
+	shares, err := strategy.MustParseMoney("$100.00").Split(3)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("$100.00 split three ways: %v\n", shares)
+
+	// A 70/30 revenue share of ¥1,001 (yen have no minor unit).
+	shares, err = strategy.MustParseMoney("1001 JPY").Allocate(70, 30)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("¥1,001 allocated 70:30: %v\n", shares)
+
+	if err := cart.AddItem(strategy.MustParseMoney("EUR 5.00")); err != nil {
+		fmt.Printf("Adding a euro price to a dollar cart: %v\n", err)
+	}
+	if _, err := strategy.ParseMoney("0.005 USD"); err != nil {
+		fmt.Printf("Sub-cent amounts are rejected: %v\n", err)
+	}
+	fmt.Println(strategy.MustParseMoney("1234567.891 KWD"), strategy.MustParseMoney("CHF -12.5"))
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hardworking-gopher/GoF/behavioral/templatemethod"
+)
+
+// --- Client Code ---
+func main() {
+	// Build a Wooden House
+	fmt.Println("Client: Requesting a Wooden House.")
+	woodenBuilder := &templatemethod.WoodenHouseBuilder{}
+	woodenHouseProcess := templatemethod.NewConstructionProcess(woodenBuilder) // Client provides the specific builder
+	woodenHouseProcess.BuildHouse()
+
+	// Build a Brick House
+	fmt.Println("\nClient: Requesting a Brick House.")
+	brickBuilder := &templatemethod.BrickHouseBuilder{}
+	brickHouseProcess := templatemethod.NewConstructionProcess(brickBuilder) // Client provides another specific builder
+	brickHouseProcess.BuildHouse()
+}
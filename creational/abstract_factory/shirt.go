@@ -1,23 +0,0 @@
-package main
-
-// Concrete Shirt implementations
-type Shirt struct {
-	logo string
-	size int
-}
-
-func (s *Shirt) setLogo(logo string) {
-	s.logo = logo
-}
-
-func (s *Shirt) getLogo() string {
-	return s.logo
-}
-
-func (s *Shirt) setSize(size int) {
-	s.size = size
-}
-
-func (s *Shirt) getSize() int {
-	return s.size
-}
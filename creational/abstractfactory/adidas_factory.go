@@ -1,16 +1,16 @@
-package main
+package abstractfactory
 
 // Adidas Factory
 type Adidas struct{}
 
-func (a *Adidas) makeShirt() IShirt {
+func (a *Adidas) MakeShirt() IShirt {
 	return &Shirt{
 		logo: "adidas",
 		size: 15,
 	}
 }
 
-func (a *Adidas) makeShoe() IShoe {
+func (a *Adidas) MakeShoe() IShoe {
 	return &Shoe{
 		logo: "adidas",
 		size: 15,
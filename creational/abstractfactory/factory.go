@@ -0,0 +1,14 @@
+package abstractfactory
+
+import "fmt"
+
+func GetSportsFactory(brand string) (ISportsFactory, error) {
+	switch brand {
+	case "adidas":
+		return &Adidas{}, nil
+	case "nike":
+		return &Nike{}, nil
+	default:
+		return nil, fmt.Errorf("wrong brand type passed")
+	}
+}
@@ -0,0 +1,20 @@
+package abstractfactory
+
+type IShirt interface {
+	SetLogo(logo string)
+	SetSize(size int)
+	GetLogo() string
+	GetSize() int
+}
+
+type IShoe interface {
+	SetLogo(logo string)
+	SetSize(size int)
+	GetLogo() string
+	GetSize() int
+}
+
+type ISportsFactory interface {
+	MakeShirt() IShirt
+	MakeShoe() IShoe
+}
@@ -1,17 +1,16 @@
-package main
-
+package abstractfactory
 
 // Nike Factory
 type Nike struct{}
 
-func (n *Nike) makeShirt() IShirt {
+func (n *Nike) MakeShirt() IShirt {
 	return &Shirt{
 		logo: "nike",
 		size: 14,
 	}
 }
 
-func (n *Nike) makeShoe() IShoe {
+func (n *Nike) MakeShoe() IShoe {
 	return &Shoe{
 		logo: "nike",
 		size: 14,
@@ -1,4 +1,4 @@
-package main
+package factorymethod
 
 import "fmt"
 
@@ -75,47 +75,3 @@ func NewNotifier(nt NotificationType, config map[string]string) (Notifier, error
 		return nil, fmt.Errorf("unknown notification type: %s", nt)
 	}
 }
-
-// --- Client Code ---
-func main() {
-	// Create an Email Notifier
-	emailConfig := map[string]string{"recipient": "user@example.com"}
-	emailNotifier, err := NewNotifier(TypeEmail, emailConfig)
-	if err != nil {
-		fmt.Println("Error creating email notifier:", err)
-	} else {
-		emailNotifier.Send("Hello via Email!")
-	}
-
-	fmt.Println()
-
-	// Create an SMS Notifier
-	smsConfig := map[string]string{"phone_number": "123-456-7890"}
-	smsNotifier, err := NewNotifier(TypeSMS, smsConfig)
-	if err != nil {
-		fmt.Println("Error creating SMS notifier:", err)
-	} else {
-		smsNotifier.Send("Hello via SMS!")
-	}
-
-	fmt.Println()
-
-	// Create a Push Notifier
-	pushConfig := map[string]string{"device_token": "abcdef123456"}
-	pushNotifier, err := NewNotifier(TypePush, pushConfig)
-	if err != nil {
-		fmt.Println("Error creating push notifier:", err)
-	} else {
-		pushNotifier.Send("Hello via Push!")
-	}
-
-	fmt.Println()
-
-	// Try to create an unknown notifier
-	unknownNotifier, err := NewNotifier("unknown_type", nil)
-	if err != nil {
-		fmt.Println("Error creating unknown notifier:", err)
-	} else {
-		unknownNotifier.Send("This won't happen.")
-	}
-}
@@ -1,4 +1,4 @@
-package main
+package prototype
 
 import "fmt"
 
@@ -7,11 +7,15 @@ type File struct {
 	name string
 }
 
-func (f *File) print(indentation string) {
+func NewFile(name string) *File {
+	return &File{name: name}
+}
+
+func (f *File) Print(indentation string) {
 	fmt.Println(indentation + f.name)
 }
 
-func (f *File) clone() Inode {
+func (f *File) Clone() Inode {
 	// Create a new File instance with the same name
 	return &File{name: f.name}
 }
@@ -1,4 +1,4 @@
-package main
+package prototype
 
 import "fmt"
 
@@ -8,20 +8,32 @@ type Folder struct {
 	name     string
 }
 
-func (f *Folder) print(indentation string) {
+func NewFolder(name string, children ...Inode) *Folder {
+	return &Folder{name: name, children: children}
+}
+
+func (f *Folder) SetName(name string) {
+	f.name = name
+}
+
+func (f *Folder) Add(child Inode) {
+	f.children = append(f.children, child)
+}
+
+func (f *Folder) Print(indentation string) {
 	fmt.Println(indentation + f.name)
 	for _, child := range f.children {
-		child.print(indentation + indentation)
+		child.Print(indentation + indentation)
 	}
 }
 
-func (f *Folder) clone() Inode {
+func (f *Folder) Clone() Inode {
 	// Create a new Folder
 	clonedFolder := &Folder{name: f.name}
 	var tempChildren []Inode
 	// Clone each child node recursively
 	for _, child := range f.children {
-		tempChildren = append(tempChildren, child.clone())
+		tempChildren = append(tempChildren, child.Clone())
 	}
 	clonedFolder.children = tempChildren
 	return clonedFolder
@@ -1,7 +1,7 @@
-package main
+package prototype
 
 // Prototype: Interface for cloning
 type Inode interface {
-	print(indentation string)
-	clone() Inode
+	Print(indentation string)
+	Clone() Inode
 }
@@ -0,0 +1,40 @@
+package singleton
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DBConnection is what callers get from GetDBInstance.
+type DBConnection interface {
+	GetConnectionString() string
+}
+
+// The databaseConnection struct is our singleton object. It is unexported so the
+// only way to obtain one is GetDBInstance.
+type databaseConnection struct {
+	connectionString string
+}
+
+var (
+	instance *databaseConnection
+	once     sync.Once
+)
+
+// GetDBInstance is the global access point for the singleton instance.
+// It uses sync.Once to ensure the instance is created only once.
+func GetDBInstance() DBConnection {
+	once.Do(func() {
+		// This function will only be executed the very first time GetDBInstance is called.
+		fmt.Println("Creating database connection instance now.")
+		instance = &databaseConnection{
+			connectionString: "server=my-db;user=root;password=secret;",
+		}
+	})
+	return instance
+}
+
+// A method on our singleton to demonstrate its usage.
+func (db *databaseConnection) GetConnectionString() string {
+	return db.connectionString
+}
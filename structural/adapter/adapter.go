@@ -1,4 +1,4 @@
-package main
+package adapter
 
 import "fmt"
 
@@ -57,21 +57,3 @@ func ClientCode(processor DataProcessor, input string) {
 	}
 	fmt.Printf("Client: Received processed data: '%s'\n", result)
 }
-
-// --- Main function to demonstrate usage ---
-func main() {
-	// Scenario 1: Directly using the Adaptee (not compatible with ClientCode)
-	// legacyConverter := &LegacyDataConverter{}
-	// This would not compile: ClientCode(legacyConverter, "test") because LegacyDataConverter does not implement DataProcessor
-
-	// Scenario 2: Using the Adapter to make the Adaptee compatible
-	fmt.Println("--- Using the Adapter ---")
-	legacyConverter := &LegacyDataConverter{}
-	adapter := NewLegacyDataConverterAdapter(legacyConverter)
-
-	// Now, the client code can use the adapter seamlessly
-	ClientCode(adapter, "hello_world")
-
-	fmt.Println("\n--- Another Use Case ---")
-	ClientCode(adapter, "sample_data")
-}
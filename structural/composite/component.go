@@ -1,5 +1,5 @@
-package main
+package composite
 
 type Component interface {
-	search(string)
+	Search(string)
 }
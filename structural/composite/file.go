@@ -1,4 +1,4 @@
-package main
+package composite
 
 import "fmt"
 
@@ -6,10 +6,14 @@ type File struct {
 	name string
 }
 
-func (f *File) search(keyword string) {
+func NewFile(name string) *File {
+	return &File{name: name}
+}
+
+func (f *File) Search(keyword string) {
 	fmt.Printf("Searching for keyword %s in file %s\n", keyword, f.name)
 }
 
-func (f *File) getName() string {
+func (f *File) GetName() string {
 	return f.name
 }
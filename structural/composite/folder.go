@@ -1,4 +1,4 @@
-package main
+package composite
 
 import "fmt"
 
@@ -7,13 +7,17 @@ type Folder struct {
 	name       string
 }
 
-func (f *Folder) search(keyword string) {
+func NewFolder(name string) *Folder {
+	return &Folder{name: name}
+}
+
+func (f *Folder) Search(keyword string) {
 	fmt.Printf("Serching recursively for keyword %s in folder %s\n", keyword, f.name)
 	for _, composite := range f.components {
-		composite.search(keyword)
+		composite.Search(keyword)
 	}
 }
 
-func (f *Folder) add(c Component) {
+func (f *Folder) Add(c Component) {
 	f.components = append(f.components, c)
 }
@@ -1,6 +1,4 @@
-package main
-
-import "fmt"
+package decorator
 
 // --- 1. Component (Interface) ---
 // Defines the common interface for coffee and its condiments.
@@ -73,38 +71,3 @@ func (ca *Caramel) GetCost() float64 {
 func (ca *Caramel) GetDescription() string {
 	return ca.Coffee.GetDescription() + ", Caramel" // Add caramel description
 }
-
-// --- Client Code ---
-func main() {
-	// Start with a simple coffee
-	myCoffee := &SimpleCoffee{}
-	fmt.Printf("Base Coffee: %s - $%.2f\n", myCoffee.GetDescription(), myCoffee.GetCost())
-
-	fmt.Println("\n--- Adding Condiments ---")
-
-	// Add Milk
-	milkCoffee := NewMilk(myCoffee)
-	fmt.Printf("Milk Coffee: %s - $%.2f\n", milkCoffee.GetDescription(), milkCoffee.GetCost())
-
-	// Add Sugar to the milk coffee
-	milkSugarCoffee := NewSugar(milkCoffee)
-	fmt.Printf("Milk & Sugar Coffee: %s - $%.2f\n", milkSugarCoffee.GetDescription(), milkSugarCoffee.GetCost())
-
-	// Start fresh, add caramel and then milk
-	caramelCoffee := NewCaramel(&SimpleCoffee{}) // Start with a new simple coffee
-	caramelMilkCoffee := NewMilk(caramelCoffee)
-	fmt.Printf("Caramel & Milk Coffee: %s - $%.2f\n", caramelMilkCoffee.GetDescription(), caramelMilkCoffee.GetCost())
-
-	fmt.Println("\n--- Complex Order ---")
-	// Order a coffee with milk, sugar, and extra caramel
-	complexCoffee := NewCaramel(NewSugar(NewMilk(&SimpleCoffee{})))
-	fmt.Printf("Complex Coffee: %s - $%.2f\n", complexCoffee.GetDescription(), complexCoffee.GetCost())
-
-	// Client code always interacts with the `Coffee` interface,
-	// regardless of how many decorators are applied.
-	describeAndCost(complexCoffee)
-}
-
-func describeAndCost(c Coffee) {
-	fmt.Printf("Final Order: %s | Total Cost: $%.2f\n", c.GetDescription(), c.GetCost())
-}
@@ -1,10 +1,10 @@
-package main
+package flyweight
 
 type CounterTerroristDress struct {
 	color string
 }
 
-func (c *CounterTerroristDress) getColor() string {
+func (c *CounterTerroristDress) GetColor() string {
 	return c.color
 }
 
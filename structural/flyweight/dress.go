@@ -1,5 +1,5 @@
-package main
+package flyweight
 
 type Dress interface {
-	getColor() string
+	GetColor() string
 }
@@ -1,6 +1,9 @@
-package main
+package flyweight
 
-import "fmt"
+import (
+	"fmt"
+	"maps"
+)
 
 const (
 	//TerroristDressType terrorist dress type
@@ -19,7 +22,7 @@ type DressFactory struct {
 	dressMap map[string]Dress
 }
 
-func (d *DressFactory) getDressByType(dressType string) (Dress, error) {
+func (d *DressFactory) GetDressByType(dressType string) (Dress, error) {
 	if d.dressMap[dressType] != nil {
 		return d.dressMap[dressType], nil
 	}
@@ -36,6 +39,10 @@ func (d *DressFactory) getDressByType(dressType string) (Dress, error) {
 	return nil, fmt.Errorf("wrong dress type passed")
 }
 
-func getDressFactorySingleInstance() *DressFactory {
+func (d *DressFactory) Dresses() map[string]Dress {
+	return maps.Clone(d.dressMap)
+}
+
+func GetDressFactorySingleInstance() *DressFactory {
 	return dressFactorySingleInstance
 }
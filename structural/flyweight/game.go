@@ -1,25 +1,31 @@
-package main
+package flyweight
 
-type game struct {
+type Game struct {
 	terrorists        []*Player
 	counterTerrorists []*Player
 }
 
-func newGame() *game {
-	return &game{
+func NewGame() *Game {
+	return &Game{
 		terrorists:        make([]*Player, 0, 1),
 		counterTerrorists: make([]*Player, 0, 1),
 	}
 }
 
-func (c *game) addTerrorist(dressType string) {
+func (c *Game) AddTerrorist(dressType string) {
 	player := newPlayer("T", dressType)
 	c.terrorists = append(c.terrorists, player)
 	return
 }
 
-func (c *game) addCounterTerrorist(dressType string) {
+func (c *Game) AddCounterTerrorist(dressType string) {
 	player := newPlayer("CT", dressType)
 	c.counterTerrorists = append(c.counterTerrorists, player)
 	return
 }
+
+func (c *Game) Players() []*Player {
+	players := make([]*Player, 0, len(c.terrorists)+len(c.counterTerrorists))
+	players = append(players, c.terrorists...)
+	return append(players, c.counterTerrorists...)
+}
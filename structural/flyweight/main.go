@@ -1,32 +0,0 @@
-package main
-
-import "fmt"
-
-func main() {
-	game := newGame()
-
-	//Add Terrorist
-	game.addTerrorist(TerroristDressType)
-	game.addTerrorist(TerroristDressType)
-	game.addTerrorist(TerroristDressType)
-	game.addTerrorist(TerroristDressType)
-
-	//Add CounterTerrorist
-	game.addCounterTerrorist(CounterTerroristDressType)
-	game.addCounterTerrorist(CounterTerroristDressType)
-	game.addCounterTerrorist(CounterTerroristDressType)
-
-	dressFactoryInstance := getDressFactorySingleInstance()
-
-	for dressType, dress := range dressFactoryInstance.dressMap {
-		fmt.Printf("DressColorType: %s\nDressColor: %s\n", dressType, dress.getColor())
-	}
-
-	uniquePointers := make(map[string]bool)
-	for _, player := range append(game.terrorists, game.counterTerrorists...) {
-		uniquePointers[fmt.Sprintf("%p", player.dress)] = true
-	}
-
-	// even though we have 10 players, they all share two pointers of the dress structs
-	fmt.Println(len(uniquePointers))
-}
@@ -1,4 +1,4 @@
-package main
+package flyweight
 
 type Player struct {
 	dress      Dress
@@ -8,14 +8,18 @@ type Player struct {
 }
 
 func newPlayer(playerType, dressType string) *Player {
-	dress, _ := getDressFactorySingleInstance().getDressByType(dressType)
+	dress, _ := GetDressFactorySingleInstance().GetDressByType(dressType)
 	return &Player{
 		playerType: playerType,
 		dress:      dress,
 	}
 }
 
-func (p *Player) newLocation(lat, long int) {
+func (p *Player) Dress() Dress {
+	return p.dress
+}
+
+func (p *Player) NewLocation(lat, long int) {
 	p.lat = lat
 	p.long = long
 }
@@ -1,10 +1,10 @@
-package main
+package flyweight
 
 type TerroristDress struct {
 	color string
 }
 
-func (t *TerroristDress) getColor() string {
+func (t *TerroristDress) GetColor() string {
 	return t.color
 }
 
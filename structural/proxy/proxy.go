@@ -1,4 +1,4 @@
-package main
+package proxy
 
 import "fmt"
 import "errors"
@@ -68,52 +68,3 @@ func (dp *DocumentProtectionProxy) ReadContent() (string, error) {
 	// Delegate the call to the Real Subject
 	return dp.realDocument.ReadContent()
 }
-
-// --- Client Code ---
-func main() {
-	// Create a real document
-	secretDoc := NewRealDocument("This is highly confidential information.")
-
-	// --- Scenario 1: Admin user accessing document ---
-	adminUser := &User{Name: "Admin", Roles: []string{"admin"}}
-	adminProxy := NewDocumentProtectionProxy(secretDoc, adminUser)
-	fmt.Println("--- Admin User Attempt ---")
-	content, err := adminProxy.ReadContent()
-	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-	} else {
-		fmt.Printf("Admin read: '%s'\n", content)
-	}
-
-	fmt.Println("\n--- Viewer User Attempt ---")
-	// --- Scenario 2: Viewer user accessing document ---
-	viewerUser := &User{Name: "Viewer", Roles: []string{"viewer"}}
-	viewerProxy := NewDocumentProtectionProxy(secretDoc, viewerUser)
-	content, err = viewerProxy.ReadContent()
-	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-	} else {
-		fmt.Printf("Viewer read: '%s'\n", content)
-	}
-
-	fmt.Println("\n--- Guest User Attempt (No role) ---")
-	// --- Scenario 3: Guest user (no specific role) accessing document ---
-	guestUser := &User{Name: "Guest", Roles: []string{"guest"}} // Or just {}
-	guestProxy := NewDocumentProtectionProxy(secretDoc, guestUser)
-	content, err = guestProxy.ReadContent()
-	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-	} else {
-		fmt.Printf("Guest read: '%s'\n", content) // This should not happen
-	}
-
-	fmt.Println("\n--- Unauthenticated Attempt ---")
-	// --- Scenario 4: Nil user (unauthenticated) accessing document ---
-	unauthProxy := NewDocumentProtectionProxy(secretDoc, nil)
-	content, err = unauthProxy.ReadContent()
-	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-	} else {
-		fmt.Printf("Unauthenticated read: '%s'\n", content) // This should not happen
-	}
-}